@@ -53,7 +53,7 @@ func TxDemo() {
 		return
 	}
 	if !synced {
-		fmt.Println("node have not synced")
+		fmt.Println("node is not synced")
 		return
 	}
 
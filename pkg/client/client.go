@@ -5,67 +5,233 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/NpoolPlatform/chia-client/pkg/puzzlehash"
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
 	"github.com/chia-network/go-chia-libs/pkg/types"
 )
 
 type Client struct {
-	fullNodeService *FullNodeService
-	walletService   *WalletService
+	pool             *NodePool
+	quorum           *QuorumConfig
+	walletService    *WalletService
+	farmerService    *FarmerService
+	harvesterService *HarvesterService
+	dataLayerService *DataLayerService
+	nftChunkSize     int
+	walletSyncPoll   time.Duration
 }
 
-func NewClient(endpoint string) *Client {
-	return &Client{
-		fullNodeService: DefaultFullNodeService(endpoint),
-		walletService:   DefaultWalletService(endpoint),
+// NewClient creates a client for the full node at endpoint. It stays on plain http, by default the other services
+// are reached through the same plain http proxy, see DefaultConfig. NewClientFromChiaRoot talks https to a stock node
+func NewClient(endpoint string, opts ...Option) *Client {
+	cfg := DefaultConfig(endpoint)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
 	}
+	return NewClientFromConfig(cfg)
 }
 
-func (cli *Client) GetSyncStatus(ctx context.Context) (bool, error) {
-	resp, httpResp, err := cli.fullNodeService.GetBlockchainState(ctx)
+// NewClientFromChiaRoot creates a client for a stock node at endpoint, every service is reached on its default port
+// over mutual TLS with the certificates of the CHIA_ROOT at root, or of the CHIA_ROOT environment when root is empty.
+// opts are applied after these defaults
+func NewClientFromChiaRoot(endpoint, root string, opts ...Option) (*Client, error) {
+	tlsOption, err := WithChiaRootTLS(root)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	return NewClient(endpoint, append([]Option{WithDefaultPorts(), tlsOption}, opts...)...), nil
+}
 
-	if httpResp.StatusCode != 200 {
-		return false, fmt.Errorf("failed to request,status code:%v", httpResp.StatusCode)
+// NewClientFromConfig creates a client with separate full node, wallet, farmer, harvester and data layer settings
+func NewClientFromConfig(cfg *Config) *Client {
+	services := []*FullNodeService{NewFullNodeService(cfg.FullNode)}
+	for _, node := range cfg.FailoverNodes {
+		services = append(services, NewFullNodeService(node))
 	}
 
-	if resp == nil || resp.BlockchainState.ToPointer() == nil {
-		return false, fmt.Errorf("cannot get response from node")
+	nftChunkSize := cfg.NFTChunkSize
+	if nftChunkSize <= 0 {
+		nftChunkSize = DefaultNFTChunkSize
 	}
 
-	if resp.Error.ToPointer() != nil {
-		return false, fmt.Errorf(*resp.Error.ToPointer())
+	walletSyncPoll := cfg.WalletSyncInterval
+	if walletSyncPoll <= 0 {
+		walletSyncPoll = DefaultWalletSyncPollInterval
 	}
 
-	syncState := resp.BlockchainState.ToPointer().Sync
-	return syncState.Synced, nil
+	return &Client{
+		pool:             NewNodePool(services...),
+		quorum:           cfg.Quorum,
+		walletService:    NewWalletService(cfg.Wallet),
+		farmerService:    NewFarmerService(cfg.Farmer),
+		harvesterService: NewHarvesterService(cfg.Harvester),
+		dataLayerService: NewDataLayerService(cfg.DataLayer),
+		nftChunkSize:     nftChunkSize,
+		walletSyncPoll:   walletSyncPoll,
+	}
 }
 
-func (cli *Client) GetAggsigAddtionalData(ctx context.Context) (*types.Bytes32, error) {
-	resp, httpResp, err := cli.fullNodeService.GetAggsigAddtionalData(ctx, &GetAggsigAddtionalDataOptions{})
+// FullNode returns the healthiest full node RPC service of the client
+func (cli *Client) FullNode() *FullNodeService {
+	return cli.pool.Best()
+}
+
+// Pool returns the full node pool the client routes its calls through
+func (cli *Client) Pool() *NodePool {
+	return cli.pool
+}
+
+// Wallet returns the wallet RPC service used by the client
+func (cli *Client) Wallet() *WalletService {
+	return cli.walletService
+}
+
+// Farmer returns the farmer RPC service used by the client
+func (cli *Client) Farmer() *FarmerService {
+	return cli.farmerService
+}
+
+// Harvester returns the harvester RPC service used by the client
+func (cli *Client) Harvester() *HarvesterService {
+	return cli.harvesterService
+}
+
+// DataLayer returns the data layer RPC service used by the client
+func (cli *Client) DataLayer() *DataLayerService {
+	return cli.dataLayerService
+}
+
+func (cli *Client) GetSyncStatus(ctx context.Context) (bool, error) {
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) (bool, error) {
+		resp, httpResp, err := s.GetBlockchainState(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return false, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil || resp.BlockchainState.ToPointer() == nil {
+			return false, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return false, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		syncState := resp.BlockchainState.ToPointer().Sync
+		return syncState.Synced, nil
+	})
+}
+
+// EnsureSynced returns rpcerr.ErrNodeNotSynced if the full node is still syncing
+func (cli *Client) EnsureSynced(ctx context.Context) error {
+	synced, err := cli.GetSyncStatus(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !synced {
+		return rpcerr.ErrNodeNotSynced
+	}
+	return nil
+}
+
+// DefaultWalletSyncPollInterval is how often WaitWalletSynced asks the wallet for its sync status by default
+const DefaultWalletSyncPollInterval = 2 * time.Second
+
+// WaitWalletSynced logs the wallet into fingerprint and blocks until it is synced or ctx is done,
+// asking for the sync status at the interval set by WithWalletSyncInterval
+func (cli *Client) WaitWalletSynced(ctx context.Context, fingerprint int) error {
+	login, httpResp, err := cli.walletService.LogIn(ctx, &FingerprintOptions{
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return err
 	}
 
 	if httpResp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to request,status code:%v", httpResp.StatusCode)
+		return &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
 	}
 
-	if resp == nil || !resp.Success {
-		return nil, fmt.Errorf("cannot get response from node")
+	if login == nil {
+		return rpcerr.ErrNoResponse
 	}
 
-	if resp.Error.ToPointer() != nil {
-		return nil, fmt.Errorf(*resp.Error.ToPointer())
+	if login.Error.ToPointer() != nil {
+		return rpcerr.New(*login.Error.ToPointer())
+	}
+
+	if !login.Success {
+		return fmt.Errorf("fail to log in fingerprint %v", fingerprint)
 	}
 
-	return &resp.AdditionalData, nil
+	ticker := time.NewTicker(cli.walletSyncPoll)
+	defer ticker.Stop()
+
+	for {
+		resp, httpResp, err := cli.walletService.GetSyncStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		if !resp.Success {
+			return rpcerr.ErrNoResponse
+		}
+
+		if resp.Synced.OrEmpty() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (cli *Client) GetAggsigAddtionalData(ctx context.Context) (*types.Bytes32, error) {
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) (*types.Bytes32, error) {
+		resp, httpResp, err := s.GetAggsigAddtionalData(ctx, &GetAggsigAddtionalDataOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil || !resp.Success {
+			return nil, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return nil, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		return &resp.AdditionalData, nil
+	})
 }
 
 func (cli *Client) GetBalance(ctx context.Context, address string) (uint64, error) {
@@ -74,30 +240,16 @@ func (cli *Client) GetBalance(ctx context.Context, address string) (uint64, erro
 		return 0, fmt.Errorf("invalid address,err: %v", err)
 	}
 
-	resp, httpResp, err := cli.fullNodeService.GetCoinRecordsByPuzzleHash(ctx, &GetCoinRecordsByPuzzleHashOptions{
-		PuzzleHash:        *addressPH,
-		IncludeSpentCoins: false,
-	})
-
+	records, err := readFullNode(ctx, cli, "get_balance", func(s *FullNodeService) ([]types.CoinRecord, error) {
+		return getCoinRecordsByPuzzleHash(ctx, s, *addressPH)
+	}, unspentDigest)
 	if err != nil {
 		return 0, err
 	}
 
-	if httpResp.StatusCode != 200 {
-		return 0, fmt.Errorf("failed to request,status code:%v", httpResp.StatusCode)
-	}
-
-	if resp == nil {
-		return 0, fmt.Errorf("cannot get response from node")
-	}
-
-	if resp.Error.ToPointer() != nil {
-		return 0, fmt.Errorf(*resp.Error.ToPointer())
-	}
-
 	total := uint64(0)
 	_total := uint64(0) // test for overflow
-	for _, records := range resp.CoinRecords {
+	for _, records := range records {
 		total += records.Coin.Amount
 		if total < _total {
 			return math.MaxUint64, nil
@@ -109,7 +261,19 @@ func (cli *Client) GetBalance(ctx context.Context, address string) (uint64, erro
 }
 
 func (cli *Client) SelectCoins(ctx context.Context, totalAmount uint64, puzzleHash types.Bytes32) ([]*types.Coin, error) {
-	resp, httpResp, err := cli.fullNodeService.GetCoinRecordsByPuzzleHash(ctx, &GetCoinRecordsByPuzzleHashOptions{
+	records, err := withFullNode(ctx, cli.pool, func(s *FullNodeService) ([]types.CoinRecord, error) {
+		return getCoinRecordsByPuzzleHash(ctx, s, puzzleHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return selectCoins(totalAmount, records)
+}
+
+// getCoinRecordsByPuzzleHash returns the unspent coin records of puzzleHash
+func getCoinRecordsByPuzzleHash(ctx context.Context, s *FullNodeService, puzzleHash types.Bytes32) ([]types.CoinRecord, error) {
+	resp, httpResp, err := s.GetCoinRecordsByPuzzleHash(ctx, &GetCoinRecordsByPuzzleHashOptions{
 		PuzzleHash:        puzzleHash,
 		IncludeSpentCoins: false,
 	})
@@ -119,127 +283,222 @@ func (cli *Client) SelectCoins(ctx context.Context, totalAmount uint64, puzzleHa
 	}
 
 	if httpResp.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to request,status code:%v", httpResp.StatusCode)
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
 	}
 
 	if resp == nil {
-		return nil, fmt.Errorf("cannot get response from node")
+		return nil, rpcerr.ErrNoResponse
 	}
 
 	if resp.Error.ToPointer() != nil {
-		return nil, fmt.Errorf(*resp.Error.ToPointer())
+		return nil, rpcerr.New(*resp.Error.ToPointer())
 	}
 
-	return selectCoins(totalAmount, resp.CoinRecords)
+	return resp.CoinRecords, nil
 }
 
 func (cli *Client) PushTX(ctx context.Context, SpendBundle *types.SpendBundle) (string, error) {
-	resp, httpResp, err := cli.fullNodeService.PushTX(ctx, &FullNodePushTXOptions{*SpendBundle})
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) (string, error) {
+		resp, httpResp, err := s.PushTX(ctx, &FullNodePushTXOptions{*SpendBundle})
+		if err != nil {
+			return "", err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return "", &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return "", rpcerr.ErrNoResponse
+		}
+
+		if !resp.Success || resp.Error.ToPointer() != nil {
+			var reason error
+			if resp.Error.ToPointer() != nil {
+				reason = rpcerr.New(*resp.Error.ToPointer())
+			}
+			// push_tx is retried on transient errors, a resent bundle the node already holds is a success
+			if errors.Is(reason, rpcerr.ErrAlreadyInMempool) {
+				return calTxHash(SpendBundle)
+			}
+			return "", &rpcerr.ErrInclusion{
+				Status: rpcerr.MempoolInclusionStatus(resp.Status.OrElse(string(rpcerr.MempoolFailed))),
+				Err:    reason,
+			}
+		}
+
+		return calTxHash(SpendBundle)
+	})
+}
+
+func (cli *Client) CheckTxIDInMempool(ctx context.Context, txid string) (bool, error) {
+	txidBytes32, err := parseTxID(txid)
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	if httpResp.StatusCode != 200 {
-		return "", fmt.Errorf("failed to request, status code:%v", httpResp.StatusCode)
-	}
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) (bool, error) {
+		_, err := getMempoolItem(ctx, s, txidBytes32)
+		if errors.Is(err, rpcerr.ErrNotInMempool) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
 
-	if resp == nil {
-		return "", fmt.Errorf("cannot get response from node")
+// GetMempoolItem returns the mempool item of txid, rpcerr.ErrNotInMempool when the node does not hold it
+func (cli *Client) GetMempoolItem(ctx context.Context, txid string) (*MempoolItem, error) {
+	txidBytes32, err := parseTxID(txid)
+	if err != nil {
+		return nil, err
 	}
 
-	if resp.Error.ToPointer() != nil {
-		return "", fmt.Errorf(*resp.Error.ToPointer())
-	}
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) (*MempoolItem, error) {
+		return getMempoolItem(ctx, s, txidBytes32)
+	})
+}
 
-	if !resp.Success {
-		return "", fmt.Errorf("failed to push tx, status:%v", resp.Status)
+// GetMempoolTxFee returns the fee and the cost of txid while it is in the mempool,
+// rpcerr.ErrNotInMempool when the node does not hold it
+func (cli *Client) GetMempoolTxFee(ctx context.Context, txid string) (fee, cost uint64, err error) {
+	item, err := cli.GetMempoolItem(ctx, txid)
+	if err != nil {
+		return 0, 0, err
 	}
+	return item.Fee, item.Cost, nil
+}
 
-	return calTxHash(SpendBundle)
+func parseTxID(txid string) (types.Bytes32, error) {
+	return parseBytes32("txid", txid)
 }
 
-func (cli *Client) CheckTxIDInMempool(ctx context.Context, txid string) (bool, error) {
-	txidBytes, err := hex.DecodeString(txid)
+func parseBytes32(name, hexStr string) (types.Bytes32, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hexStr, "0x"))
 	if err != nil {
-		return false, fmt.Errorf("invalid txid,err: %v", err)
+		return types.Bytes32{}, fmt.Errorf("invalid %v,err: %v", name, err)
 	}
 
-	txidBytes32, err := types.BytesToBytes32(txidBytes)
+	b32, err := types.BytesToBytes32(b)
 	if err != nil {
-		return false, fmt.Errorf("invalid txid,err: %v", err)
+		return types.Bytes32{}, fmt.Errorf("invalid %v,err: %v", name, err)
 	}
+	return b32, nil
+}
 
-	resp, httpResp, err := cli.fullNodeService.CheckTxIDInMempool(ctx, &CheckTxIDInMempoolOptions{
-		TxID: txidBytes32,
+func getMempoolItem(ctx context.Context, s *FullNodeService, txid types.Bytes32) (*MempoolItem, error) {
+	resp, httpResp, err := s.CheckTxIDInMempool(ctx, &CheckTxIDInMempoolOptions{
+		TxID: txid,
 	})
 
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if httpResp.StatusCode != 200 {
-		return false, fmt.Errorf("failed to request, status code:%v", httpResp.StatusCode)
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
 	}
 
 	if resp == nil {
-		return false, fmt.Errorf("cannot get response from node")
+		return nil, rpcerr.ErrNoResponse
 	}
 
-	errMsg := resp.Error.ToPointer()
-	if errMsg != nil && strings.Contains(*errMsg, "not in the mempool") {
-		return false, nil
+	if resp.Error.ToPointer() != nil {
+		return nil, rpcerr.New(*resp.Error.ToPointer())
 	}
 
-	if resp.Error.ToPointer() != nil {
-		return false, fmt.Errorf(*resp.Error.ToPointer())
+	if !resp.Success || resp.MempoolItem.ToPointer() == nil {
+		// reported as a node answer, so the pool does not take the miss for a node fault
+		return nil, &rpcerr.RPCError{Message: rpcerr.ErrNotInMempool.Error(), Kind: rpcerr.ErrNotInMempool}
 	}
 
-	if !resp.Success {
-		return false, nil
+	return resp.MempoolItem.ToPointer(), nil
+}
+
+// GetBlockCoinSpends returns every coin spent in the block of headerHash with the conditions its puzzle output
+func (cli *Client) GetBlockCoinSpends(ctx context.Context, headerHash string) ([]CoinSpendWithConditions, error) {
+	hash, err := parseBytes32("header hash", headerHash)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	return withFullNode(ctx, cli.pool, func(s *FullNodeService) ([]CoinSpendWithConditions, error) {
+		resp, httpResp, err := s.GetBlockSpendsWithConditions(ctx, &GetBlockOptions{
+			HeaderHash: hash,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return nil, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return nil, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		return resp.BlockSpendsWithConditions.OrEmpty(), nil
+	})
 }
 
 // all coin spent return true
 func (cli *Client) CheckCoinsIsSpent(ctx context.Context, coinids []string) (bool, error) {
-	resp, httpResp, err := cli.fullNodeService.GetCoinRecordsByNames(ctx,
+	records, err := readFullNode(ctx, cli, "check_coins_is_spent", func(s *FullNodeService) ([]CoinRecord, error) {
+		return getCoinRecordsByNames(ctx, s, coinids)
+	}, spentDigest)
+	if err != nil {
+		return false, err
+	}
+
+	if len(records) != len(coinids) {
+		return false, fmt.Errorf("some records not found")
+	}
+
+	for _, record := range records {
+		if !record.Spent {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// getCoinRecordsByNames returns the coin records of coinids including spent ones
+func getCoinRecordsByNames(ctx context.Context, s *FullNodeService, coinids []string) ([]CoinRecord, error) {
+	resp, httpResp, err := s.GetCoinRecordsByNames(ctx,
 		&GetCoinRecordByNamesOptions{
 			Names:             coinids,
 			IncludeSpentCoins: true,
 		},
 	)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if httpResp.StatusCode != 200 {
-		return false, fmt.Errorf("failed to request, status code:%v", httpResp.StatusCode)
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
 	}
 
 	if resp == nil {
-		return false, fmt.Errorf("cannot get response from node")
+		return nil, rpcerr.ErrNoResponse
 	}
 
 	if resp.Error.ToPointer() != nil {
-		return false, fmt.Errorf(*resp.Error.ToPointer())
+		return nil, rpcerr.New(*resp.Error.ToPointer())
 	}
 
 	if !resp.Success {
-		return false, fmt.Errorf("failed to query from node")
-	}
-
-	if len(resp.CoinRecords) != len(coinids) {
-		return false, fmt.Errorf("some records not found")
-	}
-
-	for _, record := range resp.CoinRecords {
-		if !record.Spent {
-			return false, nil
-		}
+		return nil, fmt.Errorf("failed to query from node")
 	}
 
-	return true, nil
+	return resp.CoinRecords, nil
 }
 
 func selectCoins(totalAmount uint64, coins []types.CoinRecord) ([]*types.Coin, error) {
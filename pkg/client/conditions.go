@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// ConditionOpcode is the opcode of a condition a puzzle outputs
+type ConditionOpcode uint8
+
+// Condition opcodes
+const (
+	ConditionRemark                   ConditionOpcode = 1
+	ConditionAggSigUnsafe             ConditionOpcode = 49
+	ConditionAggSigMe                 ConditionOpcode = 50
+	ConditionCreateCoin               ConditionOpcode = 51
+	ConditionReserveFee               ConditionOpcode = 52
+	ConditionCreateCoinAnnouncement   ConditionOpcode = 60
+	ConditionAssertCoinAnnouncement   ConditionOpcode = 61
+	ConditionCreatePuzzleAnnouncement ConditionOpcode = 62
+	ConditionAssertPuzzleAnnouncement ConditionOpcode = 63
+	ConditionSendMessage              ConditionOpcode = 66
+	ConditionReceiveMessage           ConditionOpcode = 67
+	ConditionAssertMyCoinID           ConditionOpcode = 70
+	ConditionAssertSecondsRelative    ConditionOpcode = 80
+	ConditionAssertSecondsAbsolute    ConditionOpcode = 81
+	ConditionAssertHeightRelative     ConditionOpcode = 82
+	ConditionAssertHeightAbsolute     ConditionOpcode = 83
+)
+
+// UnmarshalJSON accepts the opcode as a number or as the hex of its byte, which is how the node sends it
+func (o *ConditionOpcode) UnmarshalJSON(data []byte) error {
+	str := ""
+	if json.Unmarshal(data, &str) != nil {
+		var n uint8
+		err := json.Unmarshal(data, &n)
+		if err != nil {
+			return fmt.Errorf("invalid condition opcode,err: %v", err)
+		}
+		*o = ConditionOpcode(n)
+		return nil
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil || len(b) != 1 {
+		return fmt.Errorf("invalid condition opcode %v", str)
+	}
+	*o = ConditionOpcode(b[0])
+	return nil
+}
+
+// Condition is a condition a coin spend outputs, Vars are its atom arguments
+type Condition struct {
+	Opcode ConditionOpcode `json:"opcode"`
+	Vars   []HexBytes      `json:"vars"`
+}
+
+// CreateCoin returns the puzzle hash and amount of a CREATE_COIN condition, ok is false for any other condition
+func (c Condition) CreateCoin() (puzzleHash types.Bytes32, amount uint64, ok bool) {
+	if c.Opcode != ConditionCreateCoin || len(c.Vars) < 2 || len(c.Vars[0]) != 32 {
+		return puzzleHash, 0, false
+	}
+
+	// clvm ints are signed big endian, a positive amount may carry a leading zero byte
+	n := new(big.Int).SetBytes(c.Vars[1])
+	if (len(c.Vars[1]) > 0 && c.Vars[1][0]&0x80 != 0) || !n.IsUint64() {
+		return puzzleHash, 0, false
+	}
+
+	copy(puzzleHash[:], c.Vars[0])
+	return puzzleHash, n.Uint64(), true
+}
+
+// CoinSpendWithConditions is a coin spend of a block with the conditions its puzzle output
+type CoinSpendWithConditions struct {
+	CoinSpend  types.CoinSpend `json:"coin_spend"`
+	Conditions []Condition     `json:"conditions"`
+}
+
+// CreatedCoins returns the coins the spend created
+func (s *CoinSpendWithConditions) CreatedCoins() []types.Coin {
+	parent := s.CoinSpend.Coin.ID()
+	coins := []types.Coin{}
+	for _, condition := range s.Conditions {
+		puzzleHash, amount, ok := condition.CreateCoin()
+		if !ok {
+			continue
+		}
+		coins = append(coins, types.Coin{
+			ParentCoinInfo: parent,
+			PuzzleHash:     puzzleHash,
+			Amount:         amount,
+		})
+	}
+	return coins
+}
@@ -0,0 +1,51 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestBlockCoinSpends(t *testing.T) {
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"success":true,"block_spends_with_conditions":[{
+			"coin_spend":{"coin":{"parent_coin_info":"0x0101010101010101010101010101010101010101010101010101010101010101","amount":1000}},
+			"conditions":[
+				{"opcode":"0x33","vars":["0202020202020202020202020202020202020202020202020202020202020202","00c8"]},
+				{"opcode":"0x33","vars":["0303030303030303030303030303030303030303030303030303030303030303","0320"]},
+				{"opcode":52,"vars":["64"]}
+			]
+		}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient(endpointOf(server), client.WithBasePath(""))
+	cli.Pool().CheckInterval = 0
+
+	spends, err := cli.GetBlockCoinSpends(context.Background(), "0x0404040404040404040404040404040404040404040404040404040404040404")
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"/get_block_spends_with_conditions"}, paths)
+	if !assert.Equal(t, 1, len(spends)) {
+		t.Fatal("spend missing")
+	}
+	assert.Equal(t, client.ConditionReserveFee, spends[0].Conditions[2].Opcode)
+
+	created := spends[0].CreatedCoins()
+	if assert.Equal(t, 2, len(created)) {
+		assert.Equal(t, uint64(200), created[0].Amount)
+		assert.Equal(t, uint64(800), created[1].Amount)
+		assert.Equal(t, spends[0].CoinSpend.Coin.ID(), created[1].ParentCoinInfo)
+	}
+
+	_, err = cli.GetBlockCoinSpends(context.Background(), "zz")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,335 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+const (
+	DefaultFullNodePort  = 8555
+	DefaultWalletPort    = 9256
+	DefaultFarmerPort    = 8559
+	DefaultHarvesterPort = 8560
+	DefaultDataLayerPort = 8562
+)
+
+// ServiceConfig is the connection setting of a single RPC service
+type ServiceConfig struct {
+	Endpoint string // host:port
+	BasePath string // only needed behind a proxy
+	Timeout  time.Duration
+	TLS      *TLSConfig   // nil speaks plain http
+	Retry    *RetryPolicy // nil sends every request once
+}
+
+// Config describes how a Client reaches the full node, the wallet, the farmer, the harvester and the data layer
+type Config struct {
+	FullNode      ServiceConfig
+	FailoverNodes []ServiceConfig // further full nodes pooled with FullNode
+	Wallet        ServiceConfig
+	Farmer        ServiceConfig
+	Harvester     ServiceConfig
+	DataLayer     ServiceConfig
+	Quorum        *QuorumConfig // nil reads coin state from the healthiest node only
+	NFTChunkSize  int           // NFTs per call of the bulk NFT helpers, 0 uses DefaultNFTChunkSize
+	// how often WaitWalletSynced asks the wallet for its sync status, 0 uses DefaultWalletSyncPollInterval
+	WalletSyncInterval time.Duration
+}
+
+// Option customizes the Config built by NewClient
+type Option func(*Config)
+
+// WithFullNodeEndpoint sets host:port of the full node RPC
+func WithFullNodeEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.Endpoint = endpoint
+	}
+}
+
+// WithFailoverEndpoints adds full nodes that copy the full node settings made by the options before it
+func WithFailoverEndpoints(endpoints ...string) Option {
+	return func(cfg *Config) {
+		for _, endpoint := range endpoints {
+			node := cfg.FullNode
+			node.Endpoint = endpoint
+			cfg.FailoverNodes = append(cfg.FailoverNodes, node)
+		}
+	}
+}
+
+// WithFailoverNodes adds full nodes with their own settings, e.g. the certificates of another installation
+func WithFailoverNodes(nodes ...ServiceConfig) Option {
+	return func(cfg *Config) {
+		cfg.FailoverNodes = append(cfg.FailoverNodes, nodes...)
+	}
+}
+
+// WithQuorum makes balance and spent status reads query nodes of the pool and require required of them to agree,
+// the reads fail with ErrInvalidQuorum unless required <= nodes <= the pool size
+func WithQuorum(nodes, required int) Option {
+	return func(cfg *Config) {
+		cfg.Quorum = &QuorumConfig{
+			Nodes:    nodes,
+			Required: required,
+		}
+	}
+}
+
+// WithQuorumConfig sets the quorum read settings, nil disables quorum reads
+func WithQuorumConfig(quorum *QuorumConfig) Option {
+	return func(cfg *Config) {
+		cfg.Quorum = quorum
+	}
+}
+
+// WithNFTChunkSize sets how many NFTs the bulk NFT helpers put in one wallet call
+func WithNFTChunkSize(size int) Option {
+	return func(cfg *Config) {
+		cfg.NFTChunkSize = size
+	}
+}
+
+// WithWalletSyncInterval sets how often WaitWalletSynced asks the wallet for its sync status
+func WithWalletSyncInterval(interval time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.WalletSyncInterval = interval
+	}
+}
+
+// WithWalletEndpoint sets host:port of the wallet RPC
+func WithWalletEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.Wallet.Endpoint = endpoint
+	}
+}
+
+// WithFarmerEndpoint sets host:port of the farmer RPC
+func WithFarmerEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.Farmer.Endpoint = endpoint
+	}
+}
+
+// WithBasePath sets the path prefix of all services, empty for a stock node
+func WithBasePath(basePath string) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.BasePath = basePath
+		cfg.Wallet.BasePath = basePath
+		cfg.Farmer.BasePath = basePath
+		cfg.Harvester.BasePath = basePath
+		cfg.DataLayer.BasePath = basePath
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].BasePath = basePath
+		}
+	}
+}
+
+// WithHarvesterEndpoint sets host:port of the harvester RPC
+func WithHarvesterEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.Harvester.Endpoint = endpoint
+	}
+}
+
+// WithDataLayerEndpoint sets host:port of the data layer RPC
+func WithDataLayerEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.DataLayer.Endpoint = endpoint
+	}
+}
+
+// WithFullNodeBasePath sets the path prefix of the full node RPC
+func WithFullNodeBasePath(basePath string) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.BasePath = basePath
+	}
+}
+
+// WithWalletBasePath sets the path prefix of the wallet RPC
+func WithWalletBasePath(basePath string) Option {
+	return func(cfg *Config) {
+		cfg.Wallet.BasePath = basePath
+	}
+}
+
+// WithTimeout sets the request timeout of all services
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.Timeout = timeout
+		cfg.Wallet.Timeout = timeout
+		cfg.Farmer.Timeout = timeout
+		cfg.Harvester.Timeout = timeout
+		cfg.DataLayer.Timeout = timeout
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].Timeout = timeout
+		}
+	}
+}
+
+// WithFullNodeTimeout sets the request timeout of the full node RPC
+func WithFullNodeTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.Timeout = timeout
+	}
+}
+
+// WithWalletTimeout sets the request timeout of the wallet RPC
+func WithWalletTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.Wallet.Timeout = timeout
+	}
+}
+
+// WithFullNodeTLS sets the certificates used with the full node, nil speaks plain http
+func WithFullNodeTLS(tlsConfig *TLSConfig) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.TLS = tlsConfig
+	}
+}
+
+// WithWalletTLS sets the certificates used with the wallet, nil speaks plain http
+func WithWalletTLS(tlsConfig *TLSConfig) Option {
+	return func(cfg *Config) {
+		cfg.Wallet.TLS = tlsConfig
+	}
+}
+
+// WithFarmerTLS sets the certificates used with the farmer, nil speaks plain http
+func WithFarmerTLS(tlsConfig *TLSConfig) Option {
+	return func(cfg *Config) {
+		cfg.Farmer.TLS = tlsConfig
+	}
+}
+
+// WithHarvesterTLS sets the certificates used with the harvester, nil speaks plain http
+func WithHarvesterTLS(tlsConfig *TLSConfig) Option {
+	return func(cfg *Config) {
+		cfg.Harvester.TLS = tlsConfig
+	}
+}
+
+// WithDataLayerTLS sets the certificates used with the data layer, nil speaks plain http
+func WithDataLayerTLS(tlsConfig *TLSConfig) Option {
+	return func(cfg *Config) {
+		cfg.DataLayer.TLS = tlsConfig
+	}
+}
+
+// WithChiaRootTLS switches every service to mutual TLS with the certificates of the CHIA_ROOT at root,
+// an empty root falls back to the CHIA_ROOT environment variable and then to ~/.chia/mainnet.
+// A stock node is reached with NewClient(endpoint, WithDefaultPorts(), tlsOption)
+func WithChiaRootTLS(root string) (Option, error) {
+	fullNode, err := TLSConfigFromChiaRoot(root, rpcinterface.ServiceFullNode)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := TLSConfigFromChiaRoot(root, rpcinterface.ServiceWallet)
+	if err != nil {
+		return nil, err
+	}
+	farmer, err := TLSConfigFromChiaRoot(root, rpcinterface.ServiceFarmer)
+	if err != nil {
+		return nil, err
+	}
+	harvester, err := TLSConfigFromChiaRoot(root, rpcinterface.ServiceHarvester)
+	if err != nil {
+		return nil, err
+	}
+	dataLayer, err := TLSConfigFromChiaRoot(root, rpcinterface.ServiceDataLayer)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(cfg *Config) {
+		cfg.FullNode.TLS = fullNode
+		cfg.Wallet.TLS = wallet
+		cfg.Farmer.TLS = farmer
+		cfg.Harvester.TLS = harvester
+		cfg.DataLayer.TLS = dataLayer
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].TLS = fullNode
+		}
+	}, nil
+}
+
+// WithDefaultPorts reaches the wallet, farmer, harvester and data layer on their default ports of the full node host,
+// and drops the base path of every service as a stock node serves its RPC at the root
+func WithDefaultPorts() Option {
+	return func(cfg *Config) {
+		cfg.Wallet.Endpoint = replacePort(cfg.FullNode.Endpoint, DefaultWalletPort)
+		cfg.Farmer.Endpoint = replacePort(cfg.FullNode.Endpoint, DefaultFarmerPort)
+		cfg.Harvester.Endpoint = replacePort(cfg.FullNode.Endpoint, DefaultHarvesterPort)
+		cfg.DataLayer.Endpoint = replacePort(cfg.FullNode.Endpoint, DefaultDataLayerPort)
+		cfg.FullNode.BasePath = ""
+		cfg.Wallet.BasePath = ""
+		cfg.Farmer.BasePath = ""
+		cfg.Harvester.BasePath = ""
+		cfg.DataLayer.BasePath = ""
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].BasePath = ""
+		}
+	}
+}
+
+// WithRetryPolicy sets the retry policy of all services, nil disables retries
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(cfg *Config) {
+		cfg.FullNode.Retry = policy
+		cfg.Wallet.Retry = policy
+		cfg.Farmer.Retry = policy
+		cfg.Harvester.Retry = policy
+		cfg.DataLayer.Retry = policy
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].Retry = policy
+		}
+	}
+}
+
+// WithPlainHTTP disables TLS for all services, undoing WithChiaRootTLS and the TLS options before it
+func WithPlainHTTP() Option {
+	return func(cfg *Config) {
+		cfg.FullNode.TLS = nil
+		cfg.Wallet.TLS = nil
+		cfg.Farmer.TLS = nil
+		cfg.Harvester.TLS = nil
+		cfg.DataLayer.TLS = nil
+		for i := range cfg.FailoverNodes {
+			cfg.FailoverNodes[i].TLS = nil
+		}
+	}
+}
+
+// DefaultConfig returns the config NewClient starts from. Every service is reached over plain http at endpoint
+// under DefaultBasePath, which is the layout of a node behind the plain http proxy. WithDefaultPorts and
+// WithChiaRootTLS switch to a stock node reached directly. Requests are sent once, a pool fails over to the next node
+// instead of retrying a dead one, WithRetryPolicy enables retries
+func DefaultConfig(endpoint string) *Config {
+	return &Config{
+		FullNode:  proxyServiceConfig(endpoint),
+		Wallet:    proxyServiceConfig(endpoint),
+		Farmer:    proxyServiceConfig(endpoint),
+		Harvester: proxyServiceConfig(endpoint),
+		DataLayer: proxyServiceConfig(endpoint),
+	}
+}
+
+// proxyServiceConfig returns the setting of a service behind the plain http proxy at endpoint
+func proxyServiceConfig(endpoint string) ServiceConfig {
+	return ServiceConfig{
+		Endpoint: endpoint,
+		BasePath: DefaultBasePath,
+		Timeout:  DefaultTimeout,
+	}
+}
+
+// replacePort keeps the host of endpoint and swaps its port
+func replacePort(endpoint string, port int) string {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	return net.JoinHostPort(host, fmt.Sprint(port))
+}
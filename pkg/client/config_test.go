@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func newRecordingServer(t *testing.T, body string, paths *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientSeparateEndpoints(t *testing.T) {
+	fullNodePaths := []string{}
+	walletPaths := []string{}
+	fullNode := newRecordingServer(t, `{"success":true,"blockchain_state":{"sync":{"synced":true}}}`, &fullNodePaths)
+	wallet := newRecordingServer(t, `{"success":true,"height":12}`, &walletPaths)
+
+	cli := client.NewClient(
+		strings.TrimPrefix(fullNode.URL, "http://"),
+		client.WithWalletEndpoint(strings.TrimPrefix(wallet.URL, "http://")),
+		client.WithFullNodeBasePath(""),
+		client.WithWalletBasePath("wallet"),
+	)
+
+	synced, err := cli.GetSyncStatus(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, synced)
+
+	height, _, err := cli.Wallet().GetHeightInfo(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(12), height.Height.OrEmpty())
+
+	assert.Equal(t, []string{"/get_blockchain_state"}, fullNodePaths)
+	assert.Equal(t, []string{"/wallet/get_height_info"}, walletPaths)
+}
+
+func TestDefaultConfigProxyLayout(t *testing.T) {
+	cfg := client.DefaultConfig("10.0.0.1:8555")
+	for _, service := range []client.ServiceConfig{cfg.FullNode, cfg.Wallet, cfg.Farmer, cfg.Harvester, cfg.DataLayer} {
+		assert.Equal(t, "10.0.0.1:8555", service.Endpoint)
+		assert.Equal(t, client.DefaultBasePath, service.BasePath)
+		assert.Nil(t, service.TLS)
+		assert.Nil(t, service.Retry)
+	}
+}
+
+func TestDefaultPorts(t *testing.T) {
+	root := newChiaRoot(t)
+	tlsOption, err := client.WithChiaRootTLS(root)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	cfg := client.DefaultConfig("10.0.0.1:8555")
+	client.WithDefaultPorts()(cfg)
+	tlsOption(cfg)
+	assert.Equal(t, "10.0.0.1:8555", cfg.FullNode.Endpoint)
+	assert.Equal(t, "10.0.0.1:9256", cfg.Wallet.Endpoint)
+	assert.Equal(t, "10.0.0.1:8559", cfg.Farmer.Endpoint)
+	assert.Equal(t, "10.0.0.1:8560", cfg.Harvester.Endpoint)
+	assert.Equal(t, "10.0.0.1:8562", cfg.DataLayer.Endpoint)
+	assert.Equal(t, "", cfg.Wallet.BasePath)
+	assert.NotEqual(t, cfg.FullNode.TLS.CertPath, cfg.Wallet.TLS.CertPath)
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// Service names the daemon starts and stops
+const (
+	ServiceNameFullNode  = "chia_full_node"
+	ServiceNameWallet    = "chia_wallet"
+	ServiceNameFarmer    = "chia_farmer"
+	ServiceNameHarvester = "chia_harvester"
+	ServiceNameTimelord  = "chia_timelord"
+	ServiceNameDataLayer = "chia_data_layer"
+	ServiceNameCrawler   = "chia_crawler"
+)
+
+// DaemonService sends keychain and service control commands to the daemon over its websocket
+type DaemonService struct {
+	*WebsocketClient
+}
+
+// NewDaemonService creates a daemon service on a websocket client, Connect must be called before use
+func NewDaemonService(ws *WebsocketClient) *DaemonService {
+	return &DaemonService{WebsocketClient: ws}
+}
+
+// DefaultDaemonService creates a daemon service at endpoint using the daemon certificates found in CHIA_ROOT
+func DefaultDaemonService(endpoint string) (*DaemonService, error) {
+	ws, err := DefaultWebsocketClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewDaemonService(ws), nil
+}
+
+func (s *DaemonService) do(ctx context.Context, command string, opts interface{}, v interface{}) error {
+	return s.Request(ctx, "daemon", command, opts, v)
+}
+
+// KeychainSecrets are the secrets of a key, only returned when asked for
+type KeychainSecrets struct {
+	Mnemonic   []string `json:"mnemonic"`
+	Entropy    HexBytes `json:"entropy"`
+	PrivateKey HexBytes `json:"private_key"`
+}
+
+// KeychainKey is a key stored in the keychain
+type KeychainKey struct {
+	Fingerprint uint32                     `json:"fingerprint"`
+	PublicKey   types.G1Element            `json:"public_key"`
+	Label       mo.Option[string]          `json:"label"`
+	Secrets     mo.Option[KeychainSecrets] `json:"secrets"`
+}
+
+// KeychainGetKeysOptions options for get_keys
+type KeychainGetKeysOptions struct {
+	IncludeSecrets bool `json:"include_secrets"`
+}
+
+// KeychainGetKeysResponse response from get_keys
+type KeychainGetKeysResponse struct {
+	Response
+	Keys mo.Option[[]KeychainKey] `json:"keys"`
+}
+
+// GetKeys returns every key of the keychain
+func (s *DaemonService) GetKeys(ctx context.Context, opts *KeychainGetKeysOptions) (*KeychainGetKeysResponse, error) {
+	r := &KeychainGetKeysResponse{}
+	err := s.do(ctx, "get_keys", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// KeychainGetKeyOptions options for get_key
+type KeychainGetKeyOptions struct {
+	Fingerprint    uint32 `json:"fingerprint"`
+	IncludeSecrets bool   `json:"include_secrets"`
+}
+
+// KeychainGetKeyResponse response from get_key
+type KeychainGetKeyResponse struct {
+	Response
+	Key mo.Option[KeychainKey] `json:"key"`
+}
+
+// GetKey returns the key of a fingerprint
+func (s *DaemonService) GetKey(ctx context.Context, opts *KeychainGetKeyOptions) (*KeychainGetKeyResponse, error) {
+	r := &KeychainGetKeyResponse{}
+	err := s.do(ctx, "get_key", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// AddPrivateKeyOptions options for add_private_key
+type AddPrivateKeyOptions struct {
+	Mnemonic string `json:"mnemonic"` // words separated by spaces
+	Label    string `json:"label,omitempty"`
+}
+
+// AddPrivateKeyResponse response from add_private_key
+type AddPrivateKeyResponse struct {
+	Response
+	Fingerprint mo.Option[uint32] `json:"fingerprint"` // only returned by newer daemons
+}
+
+// AddPrivateKey imports a mnemonic into the keychain
+func (s *DaemonService) AddPrivateKey(ctx context.Context, opts *AddPrivateKeyOptions) (*AddPrivateKeyResponse, error) {
+	r := &AddPrivateKeyResponse{}
+	err := s.do(ctx, "add_private_key", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// DeleteKeyByFingerprintOptions options for delete_key_by_fingerprint
+type DeleteKeyByFingerprintOptions struct {
+	Fingerprint uint32 `json:"fingerprint"`
+}
+
+// DeleteKeyByFingerprintResponse response from delete_key_by_fingerprint
+type DeleteKeyByFingerprintResponse struct {
+	Response
+}
+
+// DeleteKeyByFingerprint removes a key from the keychain
+func (s *DaemonService) DeleteKeyByFingerprint(ctx context.Context, opts *DeleteKeyByFingerprintOptions) (*DeleteKeyByFingerprintResponse, error) {
+	r := &DeleteKeyByFingerprintResponse{}
+	err := s.do(ctx, "delete_key_by_fingerprint", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// UnlockKeyringOptions options for unlock_keyring
+type UnlockKeyringOptions struct {
+	Passphrase string `json:"key"`
+}
+
+// UnlockKeyringResponse response from unlock_keyring
+type UnlockKeyringResponse struct {
+	Response
+}
+
+// UnlockKeyring unlocks a keyring protected by a passphrase
+func (s *DaemonService) UnlockKeyring(ctx context.Context, opts *UnlockKeyringOptions) (*UnlockKeyringResponse, error) {
+	r := &UnlockKeyringResponse{}
+	err := s.do(ctx, "unlock_keyring", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// StartServiceOptions options for start_service
+type StartServiceOptions struct {
+	Service string `json:"service"` // one of the ServiceName constants
+	Testing bool   `json:"testing,omitempty"`
+}
+
+// StartServiceResponse response from start_service
+type StartServiceResponse struct {
+	Response
+	Service mo.Option[string] `json:"service"`
+}
+
+// StartService launches a service process, it fails when the service is already running
+func (s *DaemonService) StartService(ctx context.Context, opts *StartServiceOptions) (*StartServiceResponse, error) {
+	r := &StartServiceResponse{}
+	err := s.do(ctx, "start_service", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ServiceOptions options of the commands taking a service name
+type ServiceOptions struct {
+	Service string `json:"service"` // one of the ServiceName constants
+}
+
+// StopServiceResponse response from stop_service
+type StopServiceResponse struct {
+	Response
+	ServiceName mo.Option[string] `json:"service_name"`
+}
+
+// StopService stops a running service process
+func (s *DaemonService) StopService(ctx context.Context, opts *ServiceOptions) (*StopServiceResponse, error) {
+	r := &StopServiceResponse{}
+	err := s.do(ctx, "stop_service", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// IsRunningResponse response from is_running
+type IsRunningResponse struct {
+	Response
+	ServiceName mo.Option[string] `json:"service_name"`
+	IsRunning   mo.Option[bool]   `json:"is_running"`
+}
+
+// IsRunning tells whether the daemon runs a service
+func (s *DaemonService) IsRunning(ctx context.Context, opts *ServiceOptions) (*IsRunningResponse, error) {
+	r := &IsRunningResponse{}
+	err := s.do(ctx, "is_running", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RunningServicesResponse response from running_services
+type RunningServicesResponse struct {
+	Response
+	RunningServices mo.Option[[]string] `json:"running_services"`
+}
+
+// RunningServices returns the names of the services the daemon runs
+func (s *DaemonService) RunningServices(ctx context.Context) (*RunningServicesResponse, error) {
+	r := &RunningServicesResponse{}
+	err := s.do(ctx, "running_services", nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestDaemonCommands(t *testing.T) {
+	responses := map[string]map[string]interface{}{
+		"register_service": {"success": true},
+		"running_services": {"success": true, "running_services": []string{"chia_full_node", "chia_wallet"}},
+		"get_key":          {"success": false, "error": "key not found"},
+	}
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			req := &types.WebsocketRequest{}
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			_ = conn.WriteJSON(map[string]interface{}{
+				"command":     req.Command,
+				"origin":      "daemon",
+				"destination": req.Origin,
+				"request_id":  req.RequestID,
+				"data":        responses[req.Command],
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	daemon := client.NewDaemonService(client.NewWebsocketClient(endpointOf(server), nil))
+	t.Cleanup(func() { daemon.Close() })
+	if err := daemon.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	running, err := daemon.RunningServices(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{client.ServiceNameFullNode, client.ServiceNameWallet}, running.RunningServices.OrEmpty())
+
+	key, err := daemon.GetKey(context.Background(), &client.KeychainGetKeyOptions{Fingerprint: 1})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.False(t, key.Success)
+	assert.Equal(t, "key not found", key.Error.OrEmpty())
+}
@@ -0,0 +1,536 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// HexBytes is a data layer key or value, sent as a 0x prefixed hex string
+type HexBytes []byte
+
+// String returns the 0x prefixed hex of b
+func (b HexBytes) String() string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// MarshalJSON encodes b as a 0x prefixed hex string, an empty value is "0x"
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + b.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a hex string with or without the 0x prefix
+func (b *HexBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	data = bytes.Trim(data, `"`)
+	data = bytes.TrimPrefix(data, []byte("0x"))
+
+	dest := make([]byte, hex.DecodedLen(len(data)))
+	_, err := hex.Decode(dest, data)
+	if err != nil {
+		return fmt.Errorf("invalid hex bytes,err: %v", err)
+	}
+	*b = dest
+	return nil
+}
+
+type DataLayerService struct {
+	*HttpClient
+}
+
+// NewDataLayerService creates a data layer RPC service from its connection setting
+func NewDataLayerService(cfg ServiceConfig) *DataLayerService {
+	return &DataLayerService{
+		HttpClient: &HttpClient{
+			Endpoint:    cfg.Endpoint,
+			BasePath:    cfg.BasePath,
+			Timeout:     cfg.Timeout,
+			TLS:         cfg.TLS,
+			Retry:       cfg.Retry,
+			serviceType: rpcinterface.ServiceDataLayer,
+		},
+	}
+}
+
+// DefaultDataLayerService creates a data layer RPC service behind the plain http proxy at endpoint, see DefaultConfig
+func DefaultDataLayerService(endpoint string) *DataLayerService {
+	cfg := DefaultConfig(endpoint).DataLayer
+	cfg.Endpoint = endpoint
+	return NewDataLayerService(cfg)
+}
+
+// GetVersion returns the application version for the service
+func (s *DataLayerService) GetVersion(ctx context.Context, opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_version", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetVersionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CreateDataStoreOptions options for create_data_store
+type CreateDataStoreOptions struct {
+	Fee uint64 `json:"fee"` // not required
+}
+
+// CreateDataStoreResponse response from create_data_store
+type CreateDataStoreResponse struct {
+	Response
+	ID  mo.Option[types.Bytes32]             `json:"id"`
+	Txs mo.Option[[]types.TransactionRecord] `json:"txs"`
+}
+
+// CreateDataStore creates a data store singleton owned by the wallet of the data layer
+func (s *DataLayerService) CreateDataStore(ctx context.Context, opts *CreateDataStoreOptions) (*CreateDataStoreResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "create_data_store", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateDataStoreResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetValueOptions options for get_value
+type GetValueOptions struct {
+	ID       types.Bytes32            `json:"id"`
+	Key      HexBytes                 `json:"key"`
+	RootHash mo.Option[types.Bytes32] `json:"root_hash,omitempty"` // defaults to the current root
+}
+
+// GetValueResponse response from get_value
+type GetValueResponse struct {
+	Response
+	Value mo.Option[HexBytes] `json:"value"`
+}
+
+// GetValue returns the value of a key in the store
+func (s *DataLayerService) GetValue(ctx context.Context, opts *GetValueOptions) (*GetValueResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_value", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetValueResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetKeysOptions options for get_keys and get_keys_values, without MaxPageSize everything is returned at once
+type GetKeysOptions struct {
+	ID          types.Bytes32            `json:"id"`
+	RootHash    mo.Option[types.Bytes32] `json:"root_hash,omitempty"` // defaults to the current root
+	Page        *uint32                  `json:"page,omitempty"`
+	MaxPageSize *uint32                  `json:"max_page_size,omitempty"`
+}
+
+// GetKeysResponse response from get_keys
+type GetKeysResponse struct {
+	Response
+	Keys       mo.Option[[]HexBytes]    `json:"keys"`
+	TotalPages mo.Option[uint32]        `json:"total_pages"` // only when paginated
+	TotalBytes mo.Option[uint64]        `json:"total_bytes"` // only when paginated
+	RootHash   mo.Option[types.Bytes32] `json:"root_hash"`   // only when paginated
+}
+
+// GetKeys returns the keys of the store
+func (s *DataLayerService) GetKeys(ctx context.Context, opts *GetKeysOptions) (*GetKeysResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_keys", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetKeysResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// KeyValue is a key value pair of a store with the hash of its tree node
+type KeyValue struct {
+	Hash  types.Bytes32 `json:"hash"`
+	Key   HexBytes      `json:"key"`
+	Value HexBytes      `json:"value"`
+}
+
+// GetKeysValuesResponse response from get_keys_values
+type GetKeysValuesResponse struct {
+	Response
+	KeysValues mo.Option[[]KeyValue]    `json:"keys_values"`
+	TotalPages mo.Option[uint32]        `json:"total_pages"` // only when paginated
+	TotalBytes mo.Option[uint64]        `json:"total_bytes"` // only when paginated
+	RootHash   mo.Option[types.Bytes32] `json:"root_hash"`   // only when paginated
+}
+
+// GetKeysValues returns the key value pairs of the store
+func (s *DataLayerService) GetKeysValues(ctx context.Context, opts *GetKeysOptions) (*GetKeysValuesResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_keys_values", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetKeysValuesResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// Data layer changelist actions
+const (
+	DataLayerInsert = "insert"
+	DataLayerDelete = "delete"
+	DataLayerUpsert = "upsert"
+)
+
+// DataLayerChange is an entry of the batch_update changelist, Value is not used by delete
+type DataLayerChange struct {
+	Action string   `json:"action"`
+	Key    HexBytes `json:"key"`
+	Value  HexBytes `json:"value,omitempty"`
+}
+
+// BatchUpdateOptions options for batch_update
+type BatchUpdateOptions struct {
+	ID            types.Bytes32     `json:"id"`
+	Changelist    []DataLayerChange `json:"changelist"`
+	Fee           uint64            `json:"fee"`                       // not required
+	SubmitOnChain *bool             `json:"submit_on_chain,omitempty"` // defaults to true
+}
+
+// BatchUpdateResponse response from batch_update
+type BatchUpdateResponse struct {
+	Response
+	TxID mo.Option[types.Bytes32] `json:"tx_id"` // only when submitted on chain
+}
+
+// BatchUpdate applies the changelist to the store and publishes the new root
+func (s *DataLayerService) BatchUpdate(ctx context.Context, opts *BatchUpdateOptions) (*BatchUpdateResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "batch_update", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &BatchUpdateResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DataStoreIDOptions options of the calls taking only a store id
+type DataStoreIDOptions struct {
+	ID types.Bytes32 `json:"id"`
+}
+
+// GetRootResponse response from get_root
+type GetRootResponse struct {
+	Response
+	Hash      mo.Option[types.Bytes32]   `json:"hash"`
+	Confirmed mo.Option[bool]            `json:"confirmed"`
+	Timestamp mo.Option[types.Timestamp] `json:"timestamp"`
+}
+
+// GetRoot returns the latest root of the store
+func (s *DataLayerService) GetRoot(ctx context.Context, opts *DataStoreIDOptions) (*GetRootResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_root", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetRootResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetRootsOptions options for get_roots
+type GetRootsOptions struct {
+	IDs []types.Bytes32 `json:"ids"`
+}
+
+// DataStoreRoot is the latest root of a store
+type DataStoreRoot struct {
+	ID        types.Bytes32   `json:"id"`
+	Hash      types.Bytes32   `json:"hash"`
+	Confirmed bool            `json:"confirmed"`
+	Timestamp types.Timestamp `json:"timestamp"`
+}
+
+// GetRootsResponse response from get_roots
+type GetRootsResponse struct {
+	Response
+	RootHashes mo.Option[[]DataStoreRoot] `json:"root_hashes"`
+}
+
+// GetRoots returns the latest root of several stores
+func (s *DataLayerService) GetRoots(ctx context.Context, opts *GetRootsOptions) (*GetRootsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_roots", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetRootsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RootHistoryEntry is a root the store had
+type RootHistoryEntry struct {
+	RootHash  types.Bytes32   `json:"root_hash"`
+	Confirmed bool            `json:"confirmed"`
+	Timestamp types.Timestamp `json:"timestamp"`
+}
+
+// GetRootHistoryResponse response from get_root_history
+type GetRootHistoryResponse struct {
+	Response
+	RootHistory mo.Option[[]RootHistoryEntry] `json:"root_history"`
+}
+
+// GetRootHistory returns every root the store had, the oldest first
+func (s *DataLayerService) GetRootHistory(ctx context.Context, opts *DataStoreIDOptions) (*GetRootHistoryResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_root_history", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetRootHistoryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SubscribeOptions options for subscribe
+type SubscribeOptions struct {
+	ID   types.Bytes32 `json:"id"`
+	URLs []string      `json:"urls"` // mirrors to download from besides the ones found on chain
+}
+
+// SubscribeResponse response from subscribe
+type SubscribeResponse struct {
+	Response
+}
+
+// Subscribe makes the data layer follow and download a store of someone else
+func (s *DataLayerService) Subscribe(ctx context.Context, opts *SubscribeOptions) (*SubscribeResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "subscribe", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SubscribeResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// UnsubscribeOptions options for unsubscribe
+type UnsubscribeOptions struct {
+	ID     types.Bytes32 `json:"id"`
+	Retain bool          `json:"retain"` // keep the downloaded data
+}
+
+// UnsubscribeResponse response from unsubscribe
+type UnsubscribeResponse struct {
+	Response
+}
+
+// Unsubscribe stops following a store
+func (s *DataLayerService) Unsubscribe(ctx context.Context, opts *UnsubscribeOptions) (*UnsubscribeResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "unsubscribe", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &UnsubscribeResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetKVDiffOptions options for get_kv_diff
+type GetKVDiffOptions struct {
+	ID          types.Bytes32 `json:"id"`
+	Hash1       types.Bytes32 `json:"hash_1"`
+	Hash2       types.Bytes32 `json:"hash_2"`
+	Page        *uint32       `json:"page,omitempty"`
+	MaxPageSize *uint32       `json:"max_page_size,omitempty"`
+}
+
+// Data layer diff types
+const (
+	DataLayerDiffInsert = "INSERT"
+	DataLayerDiffDelete = "DELETE"
+)
+
+// KVDiff is a key value pair inserted or deleted between two roots
+type KVDiff struct {
+	Type  string   `json:"type"`
+	Key   HexBytes `json:"key"`
+	Value HexBytes `json:"value"`
+}
+
+// GetKVDiffResponse response from get_kv_diff
+type GetKVDiffResponse struct {
+	Response
+	Diff       mo.Option[[]KVDiff] `json:"diff"`
+	TotalPages mo.Option[uint32]   `json:"total_pages"` // only when paginated
+	TotalBytes mo.Option[uint64]   `json:"total_bytes"` // only when paginated
+}
+
+// GetKVDiff returns the key value pairs that changed between two roots of the store
+func (s *DataLayerService) GetKVDiff(ctx context.Context, opts *GetKVDiffOptions) (*GetKVDiffResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_kv_diff", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetKVDiffResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetProofOptions options for get_proof
+type GetProofOptions struct {
+	StoreID types.Bytes32 `json:"store_id"`
+	Keys    []HexBytes    `json:"keys"`
+}
+
+// ProofLayer is a step of a key proof from the node up to the root
+type ProofLayer struct {
+	OtherHashSide uint8         `json:"other_hash_side"`
+	OtherHash     types.Bytes32 `json:"other_hash"`
+	CombinedHash  types.Bytes32 `json:"combined_hash"`
+}
+
+// KeyProof proves a key value pair is part of the store root
+type KeyProof struct {
+	Key      HexBytes      `json:"key"`
+	Value    HexBytes      `json:"value"`
+	NodeHash types.Bytes32 `json:"node_hash"`
+	Layers   []ProofLayer  `json:"layers"`
+}
+
+// StoreProofs are the key proofs of a store
+type StoreProofs struct {
+	StoreID types.Bytes32 `json:"store_id"`
+	Proofs  []KeyProof    `json:"proofs"`
+}
+
+// DataLayerProof proves key value pairs against the store singleton on chain, it can be passed to VerifyProof as is
+type DataLayerProof struct {
+	StoreID         types.Bytes32 `json:"store_id"`
+	CoinID          types.Bytes32 `json:"coin_id"`
+	InnerPuzzleHash types.Bytes32 `json:"inner_puzzle_hash"`
+	StoreProofs     StoreProofs   `json:"store_proofs"`
+}
+
+// GetProofResponse response from get_proof
+type GetProofResponse struct {
+	Response
+	Proof mo.Option[DataLayerProof] `json:"proof"`
+}
+
+// GetProof returns the inclusion proof of keys of an owned or subscribed store
+func (s *DataLayerService) GetProof(ctx context.Context, opts *GetProofOptions) (*GetProofResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_proof", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetProofResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VerifiedInclusion is a key value pair a proof verified
+type VerifiedInclusion struct {
+	Key   HexBytes `json:"key"`
+	Value HexBytes `json:"value"`
+}
+
+// VerifiedClvmHashes are the key value pairs a proof verified for a store
+type VerifiedClvmHashes struct {
+	StoreID    types.Bytes32       `json:"store_id"`
+	Inclusions []VerifiedInclusion `json:"inclusions"`
+}
+
+// VerifyProofResponse response from verify_proof, Success is false when the proof is invalid
+type VerifyProofResponse struct {
+	Response
+	CurrentRoot        mo.Option[bool]               `json:"current_root"` // the proven root is the latest root of the store
+	VerifiedClvmHashes mo.Option[VerifiedClvmHashes] `json:"verified_clvm_hashes"`
+}
+
+// VerifyProof checks a proof returned by GetProof against the chain
+func (s *DataLayerService) VerifyProof(ctx context.Context, proof *DataLayerProof) (*VerifyProofResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "verify_proof", proof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VerifyProofResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestHexBytesJSON(t *testing.T) {
+	b, err := json.Marshal(client.HexBytes("ab"))
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `"0x6162"`, string(b))
+
+	empty, err := json.Marshal(client.HexBytes{})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `"0x"`, string(empty))
+
+	decoded := client.HexBytes{}
+	assert.Nil(t, json.Unmarshal([]byte(`"6162"`), &decoded))
+	assert.Equal(t, client.HexBytes("ab"), decoded)
+	assert.NotNil(t, json.Unmarshal([]byte(`"0xzz"`), &decoded))
+}
+
+func TestDataLayerBatchUpdate(t *testing.T) {
+	body := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"success":true,"tx_id":"0x0202020202020202020202020202020202020202020202020202020202020202"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewDataLayerService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	resp, _, err := service.BatchUpdate(context.Background(), &client.BatchUpdateOptions{
+		Changelist: []client.DataLayerChange{
+			{Action: client.DataLayerUpsert, Key: client.HexBytes("k"), Value: client.HexBytes("v")},
+			{Action: client.DataLayerDelete, Key: client.HexBytes("old")},
+		},
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, resp.TxID.IsPresent())
+	assert.Contains(t, body, `"changelist":[{"action":"upsert","key":"0x6b","value":"0x76"},{"action":"delete","key":"0x6f6c64"}]`)
+}
@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/protocols"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/tuple"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+type FarmerService struct {
+	*HttpClient
+}
+
+// NewFarmerService creates a farmer RPC service from its connection setting
+func NewFarmerService(cfg ServiceConfig) *FarmerService {
+	return &FarmerService{
+		HttpClient: &HttpClient{
+			Endpoint:    cfg.Endpoint,
+			BasePath:    cfg.BasePath,
+			Timeout:     cfg.Timeout,
+			TLS:         cfg.TLS,
+			Retry:       cfg.Retry,
+			serviceType: rpcinterface.ServiceFarmer,
+		},
+	}
+}
+
+// DefaultFarmerService creates a farmer RPC service behind the plain http proxy at endpoint, see DefaultConfig
+func DefaultFarmerService(endpoint string) *FarmerService {
+	cfg := DefaultConfig(endpoint).Farmer
+	cfg.Endpoint = endpoint
+	return NewFarmerService(cfg)
+}
+
+// GetConnections returns connections
+func (s *FarmerService) GetConnections(ctx context.Context, opts *GetConnectionsOptions) (*GetConnectionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_connections", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &GetConnectionsResponse{}
+	resp, err := s.Do(request, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c, resp, nil
+}
+
+// GetNetworkInfo gets the network name and prefix from the farmer
+func (s *FarmerService) GetNetworkInfo(ctx context.Context, opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_network_info", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetNetworkInfoResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetVersion returns the application version for the service
+func (s *FarmerService) GetVersion(ctx context.Context, opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_version", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetVersionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SignagePointProof is a proof of space found by a plot for a signage point, a tuple in the response
+type SignagePointProof struct {
+	PlotIdentifier string
+	Proof          types.ProofOfSpace
+}
+
+// FarmerSignagePoint is a signage point seen by the farmer with the proofs its plots found
+type FarmerSignagePoint struct {
+	SignagePoint types.NewSignagePoint            `json:"signage_point"`
+	Proofs       []tuple.Tuple[SignagePointProof] `json:"proofs"`
+}
+
+// GetSignagePointsResponse response from get_signage_points
+type GetSignagePointsResponse struct {
+	Response
+	SignagePoints mo.Option[[]FarmerSignagePoint] `json:"signage_points"`
+}
+
+// GetSignagePoints returns the recent signage points and their proofs
+func (s *FarmerService) GetSignagePoints(ctx context.Context) (*GetSignagePointsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_signage_points", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetSignagePointsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetRewardTargetsOptions options for get_reward_targets
+type GetRewardTargetsOptions struct {
+	SearchForPrivateKey bool   `json:"search_for_private_key"`
+	MaxPHToSearch       uint32 `json:"max_ph_to_search,omitempty"`
+}
+
+// GetRewardTargetsResponse response from get_reward_targets
+type GetRewardTargetsResponse struct {
+	Response
+	FarmerTarget mo.Option[string] `json:"farmer_target"`
+	PoolTarget   mo.Option[string] `json:"pool_target"`
+	HaveFarmerSK mo.Option[bool]   `json:"have_farmer_sk"` // only with SearchForPrivateKey
+	HavePoolSK   mo.Option[bool]   `json:"have_pool_sk"`   // only with SearchForPrivateKey
+}
+
+// GetRewardTargets returns the farmer and pool reward addresses
+func (s *FarmerService) GetRewardTargets(ctx context.Context, opts *GetRewardTargetsOptions) (*GetRewardTargetsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_reward_targets", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetRewardTargetsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SetRewardTargetsOptions options for set_reward_targets, an empty address is left unchanged
+type SetRewardTargetsOptions struct {
+	FarmerTarget string `json:"farmer_target,omitempty"`
+	PoolTarget   string `json:"pool_target,omitempty"`
+}
+
+// SetRewardTargetsResponse response from set_reward_targets
+type SetRewardTargetsResponse struct {
+	Response
+}
+
+// SetRewardTargets changes the farmer and pool reward addresses
+func (s *FarmerService) SetRewardTargets(ctx context.Context, opts *SetRewardTargetsOptions) (*SetRewardTargetsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "set_reward_targets", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SetRewardTargetsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PoolPoints is a timestamped points record of the pool state, a tuple in the response
+type PoolPoints struct {
+	Timestamp float64
+	Points    uint64
+}
+
+// PoolConfig is the pool_list entry of the farmer config for a plot NFT
+type PoolConfig struct {
+	LauncherID            types.Bytes32   `json:"launcher_id"`
+	PoolURL               string          `json:"pool_url"`
+	PayoutInstructions    string          `json:"payout_instructions"`
+	TargetPuzzleHash      types.Bytes32   `json:"target_puzzle_hash"`
+	P2SingletonPuzzleHash types.Bytes32   `json:"p2_singleton_puzzle_hash"`
+	OwnerPublicKey        types.G1Element `json:"owner_public_key"`
+}
+
+// FarmerPoolState is the state of a plot NFT as the farmer sees it
+type FarmerPoolState struct {
+	P2SingletonPuzzleHash        types.Bytes32             `json:"p2_singleton_puzzle_hash"`
+	PoolConfig                   PoolConfig                `json:"pool_config"`
+	PlotCount                    uint32                    `json:"plot_count"`
+	CurrentDifficulty            mo.Option[uint64]         `json:"current_difficulty"`
+	CurrentPoints                uint64                    `json:"current_points"`
+	PointsFoundSinceStart        uint64                    `json:"points_found_since_start"`
+	PointsFound24h               []tuple.Tuple[PoolPoints] `json:"points_found_24h"`
+	PointsAcknowledgedSinceStart uint64                    `json:"points_acknowledged_since_start"`
+	PointsAcknowledged24h        []tuple.Tuple[PoolPoints] `json:"points_acknowledged_24h"`
+	NextFarmerUpdate             float64                   `json:"next_farmer_update"`
+	NextPoolInfoUpdate           float64                   `json:"next_pool_info_update"`
+	PoolErrors24h                []map[string]interface{}  `json:"pool_errors_24h"`
+	AuthenticationTokenTimeout   mo.Option[uint8]          `json:"authentication_token_timeout"`
+	ValidPartialsSinceStart      mo.Option[uint64]         `json:"valid_partials_since_start"`
+	InvalidPartialsSinceStart    mo.Option[uint64]         `json:"invalid_partials_since_start"`
+	StalePartialsSinceStart      mo.Option[uint64]         `json:"stale_partials_since_start"`
+	MissingPartialsSinceStart    mo.Option[uint64]         `json:"missing_partials_since_start"`
+}
+
+// GetPoolStateResponse response from get_pool_state
+type GetPoolStateResponse struct {
+	Response
+	PoolState mo.Option[[]FarmerPoolState] `json:"pool_state"`
+}
+
+// GetPoolState returns the state of every plot NFT the farmer farms
+func (s *FarmerService) GetPoolState(ctx context.Context) (*GetPoolStateResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_pool_state", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetPoolStateResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SetPayoutInstructionsOptions options for set_payout_instructions
+type SetPayoutInstructionsOptions struct {
+	LauncherID         types.Bytes32 `json:"launcher_id"`
+	PayoutInstructions string        `json:"payout_instructions"`
+}
+
+// SetPayoutInstructionsResponse response from set_payout_instructions
+type SetPayoutInstructionsResponse struct {
+	Response
+}
+
+// SetPayoutInstructions changes where the pool of a plot NFT pays out
+func (s *FarmerService) SetPayoutInstructions(ctx context.Context, opts *SetPayoutInstructionsOptions) (*SetPayoutInstructionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "set_payout_instructions", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SetPayoutInstructionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterConnection identifies a harvester connected to the farmer
+type HarvesterConnection struct {
+	NodeID types.Bytes32 `json:"node_id"`
+	Host   string        `json:"host"`
+	Port   uint16        `json:"port"`
+}
+
+// HarvesterSyncing is the plot sync progress of a harvester
+type HarvesterSyncing struct {
+	Initial            bool   `json:"initial"`
+	PlotFilesProcessed uint32 `json:"plot_files_processed"`
+	PlotFilesTotal     uint32 `json:"plot_files_total"`
+}
+
+// FarmerHarvester is a harvester with its plots as reported by get_harvesters
+type FarmerHarvester struct {
+	Connection             HarvesterConnection             `json:"connection"`
+	Plots                  []protocols.Plot                `json:"plots"`
+	FailedToOpenFilenames  []string                        `json:"failed_to_open_filenames"`
+	NoKeyFilenames         []string                        `json:"no_key_filenames"`
+	Duplicates             []string                        `json:"duplicates"`
+	TotalPlotSize          uint64                          `json:"total_plot_size"`
+	TotalEffectivePlotSize uint64                          `json:"total_effective_plot_size"`
+	Syncing                mo.Option[HarvesterSyncing]     `json:"syncing"`
+	LastSyncTime           mo.Option[types.Timestamp]      `json:"last_sync_time"`
+	HarvestingMode         mo.Option[types.HarvestingMode] `json:"harvesting_mode"`
+}
+
+// GetHarvestersResponse response from get_harvesters
+type GetHarvestersResponse struct {
+	Response
+	Harvesters mo.Option[[]FarmerHarvester] `json:"harvesters"`
+}
+
+// GetHarvesters returns every harvester of the farmer with its plots
+func (s *FarmerService) GetHarvesters(ctx context.Context) (*GetHarvestersResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_harvesters", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetHarvestersResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// FarmerHarvesterSummary is a harvester as reported by get_harvesters_summary, with counts instead of lists
+type FarmerHarvesterSummary struct {
+	Connection             HarvesterConnection             `json:"connection"`
+	Plots                  uint32                          `json:"plots"`
+	FailedToOpenFilenames  uint32                          `json:"failed_to_open_filenames"`
+	NoKeyFilenames         uint32                          `json:"no_key_filenames"`
+	Duplicates             uint32                          `json:"duplicates"`
+	TotalPlotSize          uint64                          `json:"total_plot_size"`
+	TotalEffectivePlotSize uint64                          `json:"total_effective_plot_size"`
+	Syncing                mo.Option[HarvesterSyncing]     `json:"syncing"`
+	LastSyncTime           mo.Option[types.Timestamp]      `json:"last_sync_time"`
+	HarvestingMode         mo.Option[types.HarvestingMode] `json:"harvesting_mode"`
+}
+
+// GetHarvestersSummaryResponse response from get_harvesters_summary
+type GetHarvestersSummaryResponse struct {
+	Response
+	Harvesters mo.Option[[]FarmerHarvesterSummary] `json:"harvesters"`
+}
+
+// GetHarvestersSummary returns every harvester of the farmer with plot counts
+func (s *FarmerService) GetHarvestersSummary(ctx context.Context) (*GetHarvestersSummaryResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_harvesters_summary", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetHarvestersSummaryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetPoolLoginLinkOptions options for get_pool_login_link
+type GetPoolLoginLinkOptions struct {
+	LauncherID types.Bytes32 `json:"launcher_id"`
+}
+
+// GetPoolLoginLinkResponse response from get_pool_login_link
+type GetPoolLoginLinkResponse struct {
+	Response
+	LoginLink mo.Option[string] `json:"login_link"`
+}
+
+// GetPoolLoginLink returns a link to log in to the pool of a plot NFT
+func (s *FarmerService) GetPoolLoginLink(ctx context.Context, opts *GetPoolLoginLinkOptions) (*GetPoolLoginLinkResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_pool_login_link", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetPoolLoginLinkResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestFarmerPoolState(t *testing.T) {
+	paths := []string{}
+	farmer := newRecordingServer(t, `{"success":true,"pool_state":[{
+		"p2_singleton_puzzle_hash":"0x0101010101010101010101010101010101010101010101010101010101010101",
+		"pool_config":{"pool_url":"https://pool.example","payout_instructions":"abc"},
+		"plot_count":12,
+		"current_difficulty":5,
+		"current_points":40,
+		"points_found_24h":[[1700000000.5,10],[1700000100.5,30]],
+		"points_acknowledged_24h":[],
+		"valid_partials_since_start":4
+	}]}`, &paths)
+
+	cli := client.NewClient("127.0.0.1:1",
+		client.WithFarmerEndpoint(endpointOf(farmer)),
+		client.WithBasePath(""),
+	)
+
+	resp, _, err := cli.Farmer().GetPoolState(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"/get_pool_state"}, paths)
+
+	states := resp.PoolState.OrEmpty()
+	if !assert.Equal(t, 1, len(states)) {
+		t.Fatal("pool state missing")
+	}
+	assert.Equal(t, "https://pool.example", states[0].PoolConfig.PoolURL)
+	assert.Equal(t, uint32(12), states[0].PlotCount)
+	assert.Equal(t, uint64(5), states[0].CurrentDifficulty.OrEmpty())
+	assert.Equal(t, uint64(4), states[0].ValidPartialsSinceStart.OrEmpty())
+	if assert.Equal(t, 2, len(states[0].PointsFound24h)) {
+		assert.Equal(t, uint64(30), states[0].PointsFound24h[1].Value().Points)
+	}
+}
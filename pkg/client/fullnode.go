@@ -7,6 +7,7 @@ import (
 	"github.com/samber/mo"
 
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/tuple"
 	"github.com/chia-network/go-chia-libs/pkg/types"
 )
 
@@ -14,17 +15,25 @@ type FullNodeService struct {
 	*HttpClient
 }
 
-func DefaultFullNodeService(endpoint string) *FullNodeService {
+// NewFullNodeService creates a full node RPC service from its connection setting
+func NewFullNodeService(cfg ServiceConfig) *FullNodeService {
 	return &FullNodeService{
 		HttpClient: &HttpClient{
-			Endpoint:    endpoint,
-			BasePath:    DefaultBasePath,
-			Timeout:     DefaultTimeout,
+			Endpoint:    cfg.Endpoint,
+			BasePath:    cfg.BasePath,
+			Timeout:     cfg.Timeout,
+			TLS:         cfg.TLS,
+			Retry:       cfg.Retry,
 			serviceType: rpcinterface.ServiceFullNode,
 		},
 	}
 }
 
+// DefaultFullNodeService creates a full node RPC service behind the plain http proxy at endpoint, see DefaultConfig
+func DefaultFullNodeService(endpoint string) *FullNodeService {
+	return NewFullNodeService(DefaultConfig(endpoint).FullNode)
+}
+
 // GetConnectionsOptions options to filter get_connections
 type GetConnectionsOptions struct {
 	NodeType types.NodeType `json:"node_type,omitempty"`
@@ -276,6 +285,81 @@ func (s *FullNodeService) GetAdditionsAndRemovals(ctx context.Context, opts *Get
 	return r, resp, nil
 }
 
+// GetBlockSpendsResponse response from get_block_spends
+type GetBlockSpendsResponse struct {
+	Response
+	BlockSpends mo.Option[[]types.CoinSpend] `json:"block_spends"`
+}
+
+// GetBlockSpends returns the puzzle and solution of every coin spent in a block
+func (s *FullNodeService) GetBlockSpends(ctx context.Context, opts *GetBlockOptions) (*GetBlockSpendsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_block_spends", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetBlockSpendsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetBlockSpendsWithConditionsResponse response from get_block_spends_with_conditions
+type GetBlockSpendsWithConditionsResponse struct {
+	Response
+	BlockSpendsWithConditions mo.Option[[]CoinSpendWithConditions] `json:"block_spends_with_conditions"`
+}
+
+// GetBlockSpendsWithConditions returns every coin spent in a block with the conditions its puzzle output
+func (s *FullNodeService) GetBlockSpendsWithConditions(ctx context.Context, opts *GetBlockOptions) (*GetBlockSpendsWithConditionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_block_spends_with_conditions", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetBlockSpendsWithConditionsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetBlockRecordsOptions options for get_block_records, End is exclusive
+type GetBlockRecordsOptions struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
+}
+
+// GetBlockRecordsResponse response from get_block_records
+type GetBlockRecordsResponse struct {
+	Response
+	BlockRecords mo.Option[[]types.BlockRecord] `json:"block_records"`
+}
+
+// GetBlockRecords returns the block records of a height range of the main chain
+func (s *FullNodeService) GetBlockRecords(ctx context.Context, opts *GetBlockRecordsOptions) (*GetBlockRecordsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_block_records", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetBlockRecordsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // GetCoinRecordsByPuzzleHashOptions request options for /get_coin_records_by_puzzle_hash
 type GetCoinRecordsByPuzzleHashOptions struct {
 	PuzzleHash        types.Bytes32 `json:"puzzle_hash"`
@@ -404,6 +488,37 @@ func (s *FullNodeService) GetCoinRecordsByNames(ctx context.Context, opts *GetCo
 	return r, resp, nil
 }
 
+// GetCoinRecordsByParentIDsOptions request options for /get_coin_records_by_parent_ids
+type GetCoinRecordsByParentIDsOptions struct {
+	ParentIDs         []types.Bytes32 `json:"parent_ids"`
+	IncludeSpentCoins bool            `json:"include_spent_coins"`
+	StartHeight       uint32          `json:"start_height,omitempty"`
+	EndHeight         uint32          `json:"end_height,omitempty"`
+}
+
+// GetCoinRecordsByParentIDsResponse Response for /get_coin_records_by_parent_ids
+type GetCoinRecordsByParentIDsResponse struct {
+	Response
+	CoinRecords []types.CoinRecord `json:"coin_records"`
+}
+
+// GetCoinRecordsByParentIDs returns the coin records of the children of the given coins
+func (s *FullNodeService) GetCoinRecordsByParentIDs(ctx context.Context, opts *GetCoinRecordsByParentIDsOptions) (*GetCoinRecordsByParentIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_coin_records_by_parent_ids", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetCoinRecordsByParentIDsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // GetCoinRecordsByHintOptions options for get_coin_records_by_hint
 type GetCoinRecordsByHintOptions struct {
 	Hint              types.Bytes32 `json:"hint"`
@@ -547,14 +662,151 @@ func (s *FullNodeService) CheckTxIDInMempool(ctx context.Context, opts *CheckTxI
 	return r, resp, nil
 }
 
-// GetPuzzleAndSolutionOptions options for get_puzzle_and_solution rpc call
+// CheckTxIDInMempoolOptions options for get_mempool_item_by_tx_id rpc call
 type CheckTxIDInMempoolOptions struct {
-	TxID types.Bytes32 `json:"tx_id"`
+	TxID           types.Bytes32 `json:"tx_id"`
+	IncludePending bool          `json:"include_pending,omitempty"` // also search the items waiting for a higher fee or a later height
 }
 
-// GetPuzzleAndSolutionResponse response from get_puzzle_and_solution
+// CheckTxIDInMempoolResponse response from get_mempool_item_by_tx_id
 type CheckTxIDInMempoolResponse struct {
 	Response
+	MempoolItem mo.Option[MempoolItem] `json:"mempool_item"`
+}
+
+// CreateCoinCondition is a CREATE_COIN condition of a spend, a tuple in the response
+type CreateCoinCondition struct {
+	PuzzleHash types.Bytes32
+	Amount     uint64
+	Hint       HexBytes
+}
+
+// AggSigCondition is an AGG_SIG condition of a spend, a tuple in the response
+type AggSigCondition struct {
+	PublicKey types.G1Element
+	Message   HexBytes
+}
+
+// SpendConditions are the conditions a coin spend of a mempool item created
+type SpendConditions struct {
+	CoinID                types.Bytes32                      `json:"coin_id"`
+	ParentID              types.Bytes32                      `json:"parent_id"`
+	PuzzleHash            types.Bytes32                      `json:"puzzle_hash"`
+	CoinAmount            uint64                             `json:"coin_amount"`
+	HeightRelative        mo.Option[uint32]                  `json:"height_relative"`
+	SecondsRelative       mo.Option[uint64]                  `json:"seconds_relative"`
+	BeforeHeightRelative  mo.Option[uint32]                  `json:"before_height_relative"`
+	BeforeSecondsRelative mo.Option[uint64]                  `json:"before_seconds_relative"`
+	CreateCoin            []tuple.Tuple[CreateCoinCondition] `json:"create_coin"`
+	AggSigMe              []tuple.Tuple[AggSigCondition]     `json:"agg_sig_me"`
+	Flags                 uint32                             `json:"flags"`
+}
+
+// SpendBundleConditions are the conditions of every spend of a mempool item
+type SpendBundleConditions struct {
+	Spends                []SpendConditions              `json:"spends"`
+	ReserveFee            uint64                         `json:"reserve_fee"`
+	HeightAbsolute        uint32                         `json:"height_absolute"`
+	SecondsAbsolute       uint64                         `json:"seconds_absolute"`
+	BeforeHeightAbsolute  mo.Option[uint32]              `json:"before_height_absolute"`
+	BeforeSecondsAbsolute mo.Option[uint64]              `json:"before_seconds_absolute"`
+	AggSigUnsafe          []tuple.Tuple[AggSigCondition] `json:"agg_sig_unsafe"`
+	Cost                  uint64                         `json:"cost"`
+	RemovalAmount         uint64                         `json:"removal_amount"`
+	AdditionAmount        uint64                         `json:"addition_amount"`
+}
+
+// NPCResult is the result of running the puzzles of a mempool item
+type NPCResult struct {
+	Error mo.Option[uint16]                `json:"error"`
+	Conds mo.Option[SpendBundleConditions] `json:"conds"`
+}
+
+// MempoolItem is a spend bundle in the mempool of the full node
+type MempoolItem struct {
+	SpendBundle          types.SpendBundle `json:"spend_bundle"`
+	SpendBundleName      types.Bytes32     `json:"spend_bundle_name"` // the tx id
+	Fee                  uint64            `json:"fee"`
+	Cost                 uint64            `json:"cost"`
+	NPCResult            NPCResult         `json:"npc_result"`
+	Additions            []types.Coin      `json:"additions"`
+	Removals             []types.Coin      `json:"removals"`
+	HeightAddedToMempool mo.Option[uint32] `json:"height_added_to_mempool"` // only returned by newer nodes
+}
+
+// GetAllMempoolTxIDsResponse response from get_all_mempool_tx_ids
+type GetAllMempoolTxIDsResponse struct {
+	Response
+	TxIDs mo.Option[[]types.Bytes32] `json:"tx_ids"`
+}
+
+// GetAllMempoolTxIDs returns the tx id of every mempool item
+func (s *FullNodeService) GetAllMempoolTxIDs(ctx context.Context) (*GetAllMempoolTxIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_all_mempool_tx_ids", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetAllMempoolTxIDsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetAllMempoolItemsResponse response from get_all_mempool_items, items are keyed by the hex tx id
+type GetAllMempoolItemsResponse struct {
+	Response
+	MempoolItems mo.Option[map[string]MempoolItem] `json:"mempool_items"`
+}
+
+// GetAllMempoolItems returns every mempool item, this can be large on mainnet
+func (s *FullNodeService) GetAllMempoolItems(ctx context.Context) (*GetAllMempoolItemsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_all_mempool_items", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetAllMempoolItemsResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetMempoolItemsByCoinNameOptions options for get_mempool_items_by_coin_name
+type GetMempoolItemsByCoinNameOptions struct {
+	CoinName       types.Bytes32 `json:"coin_name"`
+	IncludePending bool          `json:"include_pending,omitempty"`
+}
+
+// GetMempoolItemsByCoinNameResponse response from get_mempool_items_by_coin_name
+type GetMempoolItemsByCoinNameResponse struct {
+	Response
+	MempoolItems mo.Option[[]MempoolItem] `json:"mempool_items"`
+}
+
+// GetMempoolItemsByCoinName returns the mempool items spending a coin
+func (s *FullNodeService) GetMempoolItemsByCoinName(ctx context.Context, opts *GetMempoolItemsByCoinNameOptions) (*GetMempoolItemsByCoinNameResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_mempool_items_by_coin_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetMempoolItemsByCoinNameResponse{}
+
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
 }
 
 // FullNodePushTXOptions options for pushing tx to full node mempool
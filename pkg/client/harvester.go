@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/protocols"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+type HarvesterService struct {
+	*HttpClient
+}
+
+// NewHarvesterService creates a harvester RPC service from its connection setting
+func NewHarvesterService(cfg ServiceConfig) *HarvesterService {
+	return &HarvesterService{
+		HttpClient: &HttpClient{
+			Endpoint:    cfg.Endpoint,
+			BasePath:    cfg.BasePath,
+			Timeout:     cfg.Timeout,
+			TLS:         cfg.TLS,
+			Retry:       cfg.Retry,
+			serviceType: rpcinterface.ServiceHarvester,
+		},
+	}
+}
+
+// DefaultHarvesterService creates a harvester RPC service behind the plain http proxy at endpoint, see DefaultConfig
+func DefaultHarvesterService(endpoint string) *HarvesterService {
+	cfg := DefaultConfig(endpoint).Harvester
+	cfg.Endpoint = endpoint
+	return NewHarvesterService(cfg)
+}
+
+// GetConnections returns connections
+func (s *HarvesterService) GetConnections(ctx context.Context, opts *GetConnectionsOptions) (*GetConnectionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_connections", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &GetConnectionsResponse{}
+	resp, err := s.Do(request, c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c, resp, nil
+}
+
+// GetVersion returns the application version for the service
+func (s *HarvesterService) GetVersion(ctx context.Context, opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_version", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetVersionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetPlotsResponse response from get_plots
+type GetPlotsResponse struct {
+	Response
+	Plots                 mo.Option[[]protocols.Plot] `json:"plots"`
+	FailedToOpenFilenames mo.Option[[]string]         `json:"failed_to_open_filenames"`
+	NotFoundFilenames     mo.Option[[]string]         `json:"not_found_filenames"`
+}
+
+// GetPlots returns the plots the harvester farms and the files it could not load
+func (s *HarvesterService) GetPlots(ctx context.Context) (*GetPlotsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_plots", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetPlotsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RefreshPlotsResponse response from refresh_plots
+type RefreshPlotsResponse struct {
+	Response
+}
+
+// RefreshPlots makes the harvester rescan its plot directories
+func (s *HarvesterService) RefreshPlots(ctx context.Context) (*RefreshPlotsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "refresh_plots", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &RefreshPlotsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DeletePlotOptions options for delete_plot
+type DeletePlotOptions struct {
+	Filename string `json:"filename"`
+}
+
+// DeletePlotResponse response from delete_plot
+type DeletePlotResponse struct {
+	Response
+}
+
+// DeletePlot removes the plot file from disk
+func (s *HarvesterService) DeletePlot(ctx context.Context, opts *DeletePlotOptions) (*DeletePlotResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "delete_plot", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DeletePlotResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PlotDirectoryOptions options for add_plot_directory and remove_plot_directory
+type PlotDirectoryOptions struct {
+	Dirname string `json:"dirname"`
+}
+
+// PlotDirectoryResponse response from add_plot_directory and remove_plot_directory
+type PlotDirectoryResponse struct {
+	Response
+}
+
+// AddPlotDirectory adds a directory the harvester scans for plots
+func (s *HarvesterService) AddPlotDirectory(ctx context.Context, opts *PlotDirectoryOptions) (*PlotDirectoryResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "add_plot_directory", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PlotDirectoryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RemovePlotDirectory stops the harvester scanning a directory, the plots are kept on disk
+func (s *HarvesterService) RemovePlotDirectory(ctx context.Context, opts *PlotDirectoryOptions) (*PlotDirectoryResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "remove_plot_directory", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PlotDirectoryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetPlotDirectoriesResponse response from get_plot_directories
+type GetPlotDirectoriesResponse struct {
+	Response
+	Directories mo.Option[[]string] `json:"directories"`
+}
+
+// GetPlotDirectories returns the directories the harvester scans for plots
+func (s *HarvesterService) GetPlotDirectories(ctx context.Context) (*GetPlotDirectoriesResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_plot_directories", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetPlotDirectoriesResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterConfig is the harvesting setting returned by get_harvester_config
+type HarvesterConfig struct {
+	UseGPUHarvesting                mo.Option[bool]   `json:"use_gpu_harvesting"`
+	GPUIndex                        mo.Option[int]    `json:"gpu_index"`
+	EnforceGPUIndex                 mo.Option[bool]   `json:"enforce_gpu_index"`
+	DisableCPUAffinity              mo.Option[bool]   `json:"disable_cpu_affinity"`
+	ParallelDecompressorCount       mo.Option[int]    `json:"parallel_decompressor_count"`
+	DecompressorThreadCount         mo.Option[int]    `json:"decompressor_thread_count"`
+	RecursivePlotScan               mo.Option[bool]   `json:"recursive_plot_scan"`
+	RefreshParameterIntervalSeconds mo.Option[uint32] `json:"refresh_parameter_interval_seconds"`
+}
+
+// GetHarvesterConfigResponse response from get_harvester_config
+type GetHarvesterConfigResponse struct {
+	Response
+	HarvesterConfig
+}
+
+// GetHarvesterConfig returns the harvesting setting
+func (s *HarvesterService) GetHarvesterConfig(ctx context.Context) (*GetHarvesterConfigResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_harvester_config", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetHarvesterConfigResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// UpdateHarvesterConfigOptions options for update_harvester_config, nil fields are left unchanged.
+// The harvester must be restarted for most changes to apply
+type UpdateHarvesterConfigOptions struct {
+	UseGPUHarvesting                *bool   `json:"use_gpu_harvesting,omitempty"`
+	GPUIndex                        *int    `json:"gpu_index,omitempty"`
+	EnforceGPUIndex                 *bool   `json:"enforce_gpu_index,omitempty"`
+	DisableCPUAffinity              *bool   `json:"disable_cpu_affinity,omitempty"`
+	ParallelDecompressorCount       *int    `json:"parallel_decompressor_count,omitempty"`
+	DecompressorThreadCount         *int    `json:"decompressor_thread_count,omitempty"`
+	RecursivePlotScan               *bool   `json:"recursive_plot_scan,omitempty"`
+	RefreshParameterIntervalSeconds *uint32 `json:"refresh_parameter_interval_seconds,omitempty"`
+}
+
+// UpdateHarvesterConfigResponse response from update_harvester_config
+type UpdateHarvesterConfigResponse struct {
+	Response
+}
+
+// UpdateHarvesterConfig changes the harvesting setting
+func (s *HarvesterService) UpdateHarvesterConfig(ctx context.Context, opts *UpdateHarvesterConfigOptions) (*UpdateHarvesterConfigResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "update_harvester_config", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &UpdateHarvesterConfigResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestHarvesterUpdateConfig(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		_, _ = w.Write([]byte(`{"success":true,"use_gpu_harvesting":false,"recursive_plot_scan":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewHarvesterService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	cfg, _, err := service.GetHarvesterConfig(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, cfg.RecursivePlotScan.OrEmpty())
+	assert.False(t, cfg.GPUIndex.IsPresent())
+
+	enable := true
+	_, _, err = service.UpdateHarvesterConfig(context.Background(), &client.UpdateHarvesterConfigOptions{
+		UseGPUHarvesting: &enable,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{"use_gpu_harvesting": true}, bodies["/update_harvester_config"])
+}
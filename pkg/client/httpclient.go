@@ -7,17 +7,40 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 )
 
-// WalletService encapsulates wallet RPC methods
+// HttpClient sends RPC requests to a single chia service
 type HttpClient struct {
 	Endpoint    string // host:port
-	BasePath    string // http://host:port/basePath/request
+	BasePath    string // https://host:port/basePath/request, empty for a stock node
 	Timeout     time.Duration
+	TLS         *TLSConfig   // mutual TLS certificates, nil for plain http
+	Retry       *RetryPolicy // nil sends every request once
 	serviceType rpcinterface.ServiceType
+
+	mu     sync.Mutex
+	client *http.Client
+	built  clientSetting
+}
+
+// clientSetting is the part of HttpClient the underlying http.Client is built from
+type clientSetting struct {
+	timeout time.Duration
+	tls     TLSConfig
+	useTLS  bool
+}
+
+func (c *HttpClient) setting() clientSetting {
+	setting := clientSetting{timeout: c.Timeout}
+	if c.TLS != nil {
+		setting.tls = *c.TLS
+		setting.useTLS = true
+	}
+	return setting
 }
 
 // NewRequest creates an RPC request for the specified service
@@ -47,7 +70,7 @@ func (c *HttpClient) NewRequest(ctx context.Context, rpcEndpoint rpcinterface.En
 		}
 	}
 
-	url := fmt.Sprintf("http://%v/%v/%v", c.Endpoint, c.BasePath, rpcEndpoint)
+	url := c.url(rpcEndpoint)
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
@@ -60,18 +83,67 @@ func (c *HttpClient) NewRequest(ctx context.Context, rpcEndpoint rpcinterface.En
 	}
 
 	return &rpcinterface.Request{
-		Service: c.serviceType,
-		Request: req,
+		Service:  c.serviceType,
+		Endpoint: rpcEndpoint,
+		Data:     opt,
+		Request:  req,
 	}, nil
 }
 
+func (c *HttpClient) url(rpcEndpoint rpcinterface.Endpoint) string {
+	scheme := "http"
+	if c.TLS != nil {
+		scheme = "https"
+	}
+
+	if c.BasePath == "" {
+		return fmt.Sprintf("%v://%v/%v", scheme, c.Endpoint, rpcEndpoint)
+	}
+	return fmt.Sprintf("%v://%v/%v/%v", scheme, c.Endpoint, c.BasePath, rpcEndpoint)
+}
+
+// httpClient lazily builds the underlying http.Client so that certificates are only loaded once,
+// it is rebuilt when Timeout or TLS have changed since the last request
+func (c *HttpClient) httpClient() (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	setting := c.setting()
+	if c.client != nil && c.built == setting {
+		return c.client, nil
+	}
+
+	client := &http.Client{
+		Timeout: c.Timeout,
+	}
+
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.Load()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+	c.client = client
+	c.built = setting
+	return client, nil
+}
+
 // Do sends an RPC request and returns the RPC response.
 func (c *HttpClient) Do(req *rpcinterface.Request, v interface{}) (*http.Response, error) {
-	client := http.Client{
-		Timeout: c.Timeout,
+	client, err := c.httpClient()
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := client.Do(req.Request)
+	resp, err := c.roundTrip(client, req)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +155,7 @@ func (c *HttpClient) Do(req *rpcinterface.Request, v interface{}) (*http.Respons
 			err = json.NewDecoder(resp.Body).Decode(v)
 		}
 	}
+	resp.Body.Close()
 
 	return resp, err
 }
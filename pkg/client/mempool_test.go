@@ -0,0 +1,79 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+)
+
+const testTxID = "0303030303030303030303030303030303030303030303030303030303030303"
+
+func TestMempoolTxFee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), strings.Repeat("05", 32)) {
+			_, _ = w.Write([]byte(`{"success":true,"mempool_item":null}`))
+			return
+		}
+		if !strings.Contains(string(body), testTxID) {
+			_, _ = w.Write([]byte(`{"success":false,"error":"Tx id 0x04 not in the mempool"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"mempool_item":{
+			"spend_bundle":{"coin_spends":[]},
+			"spend_bundle_name":"0x` + testTxID + `",
+			"fee":50000,
+			"cost":11000000,
+			"npc_result":{"error":null,"conds":{"spends":[{
+				"coin_id":"0x0101010101010101010101010101010101010101010101010101010101010101",
+				"create_coin":[["0x0202020202020202020202020202020202020202020202020202020202020202",1000,null]]
+			}],"cost":11000000}},
+			"additions":[{"amount":1000}],
+			"removals":[{"amount":51000}]
+		}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient(endpointOf(server), client.WithBasePath(""))
+	cli.Pool().CheckInterval = 0
+
+	fee, cost, err := cli.GetMempoolTxFee(context.Background(), testTxID)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(50000), fee)
+	assert.Equal(t, uint64(11000000), cost)
+
+	item, err := cli.GetMempoolItem(context.Background(), testTxID)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	spends := item.NPCResult.Conds.OrEmpty().Spends
+	if assert.Equal(t, 1, len(spends)) && assert.Equal(t, 1, len(spends[0].CreateCoin)) {
+		assert.Equal(t, uint64(1000), spends[0].CreateCoin[0].Value().Amount)
+	}
+
+	other := strings.Repeat("04", 32)
+	_, _, err = cli.GetMempoolTxFee(context.Background(), other)
+	assert.True(t, errors.Is(err, rpcerr.ErrNotInMempool))
+
+	inMempool, err := cli.CheckTxIDInMempool(context.Background(), other)
+	assert.Nil(t, err)
+	assert.False(t, inMempool)
+
+	_, err = cli.GetMempoolItem(context.Background(), strings.Repeat("05", 32))
+	assert.True(t, errors.Is(err, rpcerr.ErrNotInMempool))
+
+	// a miss is an answer of the node, not a failure counting against it
+	assert.Equal(t, 0, cli.Pool().Health()[0].Failures)
+	assert.Nil(t, cli.Pool().Health()[0].Err)
+}
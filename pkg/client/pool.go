@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+)
+
+const (
+	DefaultMaxHeightLag  = 3
+	DefaultMaxPeakAge    = 10 * time.Minute
+	DefaultCheckInterval = 30 * time.Second
+	DefaultCheckTimeout  = 5 * time.Second
+)
+
+// NodeHealth is the last observed state of a node in a NodePool
+type NodeHealth struct {
+	Endpoint   string
+	Synced     bool
+	PeakHeight uint32
+	PeakTime   time.Time // timestamp of the latest transaction block seen at the peak
+	CheckedAt  time.Time
+	Failures   int // failed calls since the last successful health check
+	Err        error
+}
+
+// PeakAge returns how long ago the node saw its latest transaction block
+func (h *NodeHealth) PeakAge(now time.Time) time.Duration {
+	if h.PeakTime.IsZero() {
+		return 0
+	}
+	return now.Sub(h.PeakTime)
+}
+
+type poolNode struct {
+	service *FullNodeService
+	health  NodeHealth
+}
+
+// NodePool routes full node calls to the healthiest of several nodes and fails over to the next one
+type NodePool struct {
+	MaxHeightLag  uint32        // nodes further behind the highest peak are ranked last
+	MaxPeakAge    time.Duration // nodes whose peak transaction block is older are ranked last, 0 disables the check
+	CheckInterval time.Duration // health older than this is refreshed in the background, 0 disables it
+	CheckTimeout  time.Duration // deadline of a background refresh, 0 leaves it to the service timeout
+
+	mu         sync.RWMutex
+	nodes      []*poolNode
+	lastCheck  time.Time
+	refreshing bool
+}
+
+// NewNodePool creates a pool over the given full nodes, the first one is preferred until health is known
+func NewNodePool(services ...*FullNodeService) *NodePool {
+	pool := &NodePool{
+		MaxHeightLag:  DefaultMaxHeightLag,
+		MaxPeakAge:    DefaultMaxPeakAge,
+		CheckInterval: DefaultCheckInterval,
+		CheckTimeout:  DefaultCheckTimeout,
+	}
+	for _, service := range services {
+		pool.nodes = append(pool.nodes, &poolNode{
+			service: service,
+			health:  NodeHealth{Endpoint: service.Endpoint},
+		})
+	}
+	return pool
+}
+
+// Check refreshes the health of every node with get_blockchain_state and returns them ranked
+func (p *NodePool) Check(ctx context.Context) []NodeHealth {
+	p.mu.RLock()
+	nodes := append([]*poolNode{}, p.nodes...)
+	p.mu.RUnlock()
+
+	results := make([]NodeHealth, len(nodes))
+	wg := sync.WaitGroup{}
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *poolNode) {
+			defer wg.Done()
+			results[i] = checkNode(ctx, node.service)
+		}(i, node)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	for i, node := range nodes {
+		if results[i].PeakTime.IsZero() && results[i].PeakHeight >= node.health.PeakHeight {
+			// the peak is not a transaction block, keep the timestamp of the last one
+			results[i].PeakTime = node.health.PeakTime
+		}
+		node.health = results[i]
+	}
+	p.lastCheck = time.Now()
+	p.mu.Unlock()
+
+	return p.Health()
+}
+
+func checkNode(ctx context.Context, service *FullNodeService) NodeHealth {
+	health := NodeHealth{
+		Endpoint:  service.Endpoint,
+		CheckedAt: time.Now(),
+	}
+
+	resp, httpResp, err := service.GetBlockchainState(ctx)
+	switch {
+	case err != nil:
+		health.Err = err
+	case httpResp.StatusCode != 200:
+		health.Err = &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	case resp == nil || resp.BlockchainState.ToPointer() == nil:
+		health.Err = rpcerr.ErrNoResponse
+	case resp.Error.ToPointer() != nil:
+		health.Err = rpcerr.New(*resp.Error.ToPointer())
+	default:
+		state := resp.BlockchainState.ToPointer()
+		health.Synced = state.Sync.Synced
+		if peak := state.Peak.ToPointer(); peak != nil {
+			health.PeakHeight = peak.Height
+			if timestamp := peak.Timestamp.ToPointer(); timestamp != nil {
+				health.PeakTime = timestamp.Time
+			}
+		}
+	}
+
+	if health.Err == nil && !health.Synced {
+		health.Err = rpcerr.ErrNodeNotSynced
+	}
+	return health
+}
+
+// Run refreshes the health of the nodes every interval until ctx is done
+func (p *NodePool) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health returns the last observed health of the nodes, healthiest first
+func (p *NodePool) Health() []NodeHealth {
+	ranked := p.ranked()
+	healths := make([]NodeHealth, 0, len(ranked))
+	p.mu.RLock()
+	for _, node := range ranked {
+		healths = append(healths, node.health)
+	}
+	p.mu.RUnlock()
+	return healths
+}
+
+// Best returns the healthiest node
+func (p *NodePool) Best() *FullNodeService {
+	ranked := p.ranked()
+	if len(ranked) == 0 {
+		return nil
+	}
+	return ranked[0].service
+}
+
+// ranked orders the nodes by health, then by peak height and failures, keeping the configured order on ties
+func (p *NodePool) ranked() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	maxHeight := uint32(0)
+	for _, node := range p.nodes {
+		if node.health.Err == nil && node.health.PeakHeight > maxHeight {
+			maxHeight = node.health.PeakHeight
+		}
+	}
+
+	now := time.Now()
+	healthy := func(h *NodeHealth) bool {
+		if h.Err != nil || h.Failures > 0 {
+			return false
+		}
+		if h.CheckedAt.IsZero() {
+			return true
+		}
+		if h.PeakHeight+p.MaxHeightLag < maxHeight {
+			return false
+		}
+		return p.MaxPeakAge <= 0 || h.PeakAge(now) <= p.MaxPeakAge
+	}
+
+	ranked := append([]*poolNode{}, p.nodes...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		hi, hj := &ranked[i].health, &ranked[j].health
+		if healthy(hi) != healthy(hj) {
+			return healthy(hi)
+		}
+		if hi.PeakHeight != hj.PeakHeight {
+			return hi.PeakHeight > hj.PeakHeight
+		}
+		return hi.Failures < hj.Failures
+	})
+	return ranked
+}
+
+func (p *NodePool) stale() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.nodes) > 1 && p.CheckInterval > 0 && time.Since(p.lastCheck) > p.CheckInterval
+}
+
+// refresh checks the nodes in the background unless a check is already running,
+// calls keep using the last ranking until it completes
+func (p *NodePool) refresh() {
+	p.mu.Lock()
+	if p.refreshing {
+		p.mu.Unlock()
+		return
+	}
+	p.refreshing = true
+	timeout := p.CheckTimeout
+	p.mu.Unlock()
+
+	go func() {
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		p.Check(ctx)
+
+		p.mu.Lock()
+		p.refreshing = false
+		p.mu.Unlock()
+	}()
+}
+
+func (p *NodePool) markFailure(node *poolNode, err error) {
+	p.mu.Lock()
+	node.health.Failures++
+	node.health.Err = err
+	p.mu.Unlock()
+}
+
+// Do runs fn against the healthiest node and moves on to the next one while fn fails on a node issue.
+// Rejections that every node would repeat, such as a double spend, are returned at once.
+// Stale health is refreshed in the background, so a slow node never delays the call itself
+func (p *NodePool) Do(ctx context.Context, fn func(*FullNodeService) error) error {
+	if p.stale() {
+		p.refresh()
+	}
+
+	ranked := p.ranked()
+	if len(ranked) == 0 {
+		return errors.New("no full node configured")
+	}
+
+	var err error
+	for _, node := range ranked {
+		err = fn(node.service)
+		if err == nil || ctx.Err() != nil || !failover(err) {
+			return err
+		}
+		p.markFailure(node, err)
+	}
+	return err
+}
+
+// failover tells whether another node may answer differently
+func failover(err error) bool {
+	var rpcErr *rpcerr.RPCError
+	var inclusionErr *rpcerr.ErrInclusion
+	if errors.As(err, &rpcErr) || errors.As(err, &inclusionErr) {
+		return rpcerr.Retryable(err)
+	}
+	return true
+}
+
+// withFullNode calls fn through the node pool of the client and returns its result
+func withFullNode[T any](ctx context.Context, pool *NodePool, fn func(*FullNodeService) (T, error)) (T, error) {
+	var result T
+	err := pool.Do(ctx, func(s *FullNodeService) error {
+		var err error
+		result, err = fn(s)
+		return err
+	})
+	return result, err
+}
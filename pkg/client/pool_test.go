@@ -0,0 +1,128 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+const testAddress = "txch1y2vqher2radvvkspad9l46jrewv63tm3huv9ewl2d37594eg3lrqtrlkgt"
+
+// newFullNodeServer serves get_blockchain_state with the given sync state and peak,
+// and a single coin of amount for get_coin_records_by_puzzle_hash
+func newFullNodeServer(t *testing.T, synced bool, height uint32, amount uint64, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/get_blockchain_state":
+			fmt.Fprintf(w, `{"success":true,"blockchain_state":{"sync":{"synced":%v},"peak":{"height":%v,"timestamp":%v}}}`,
+				synced, height, time.Now().Unix())
+		case "/get_coin_records_by_puzzle_hash":
+			atomic.AddInt32(calls, 1)
+			fmt.Fprintf(w, `{"success":true,"coin_records":[{"coin":{"amount":%v}}]}`, amount)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func endpointOf(server *httptest.Server) string {
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestPoolRoutesToHealthiest(t *testing.T) {
+	laggingCalls, syncingCalls, bestCalls := int32(0), int32(0), int32(0)
+	lagging := newFullNodeServer(t, true, 90, 1, &laggingCalls)
+	syncing := newFullNodeServer(t, false, 120, 2, &syncingCalls)
+	best := newFullNodeServer(t, true, 100, 3, &bestCalls)
+
+	cli := client.NewClient(endpointOf(lagging),
+		client.WithBasePath(""),
+		client.WithFailoverEndpoints(endpointOf(syncing), endpointOf(best)),
+	)
+
+	health := cli.Pool().Check(context.Background())
+	assert.Equal(t, endpointOf(best), health[0].Endpoint)
+
+	balance, err := cli.GetBalance(context.Background(), testAddress)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(3), balance)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&laggingCalls)+atomic.LoadInt32(&syncingCalls))
+}
+
+func TestPoolRefreshesInBackground(t *testing.T) {
+	release := make(chan struct{})
+	slowCalls := int32(0)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/get_blockchain_state" {
+			<-release
+			_, _ = w.Write([]byte(`{"success":true,"blockchain_state":{"sync":{"synced":false}}}`))
+			return
+		}
+		atomic.AddInt32(&slowCalls, 1)
+		_, _ = w.Write([]byte(`{"success":true,"coin_records":[{"coin":{"amount":1}}]}`))
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	calls := int32(0)
+	best := newFullNodeServer(t, true, 100, 3, &calls)
+
+	cli := client.NewClient(endpointOf(slow),
+		client.WithBasePath(""),
+		client.WithFailoverEndpoints(endpointOf(best)),
+	)
+
+	// the health check hangs on the first node, calls are served from the last ranking meanwhile
+	for i := 0; i < 3; i++ {
+		balance, err := cli.GetBalance(context.Background(), testAddress)
+		if !assert.Nil(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(1), balance)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&slowCalls))
+
+	release <- struct{}{}
+	assert.Eventually(t, func() bool {
+		return cli.FullNode().Endpoint == endpointOf(best)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(down.Close)
+
+	calls := int32(0)
+	up := newFullNodeServer(t, true, 100, 7, &calls)
+
+	cli := client.NewClient(endpointOf(down),
+		client.WithBasePath(""),
+		client.WithFailoverEndpoints(endpointOf(up)),
+	)
+	cli.Pool().CheckInterval = 0
+
+	balance, err := cli.GetBalance(context.Background(), testAddress)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(7), balance)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// the failed node is ranked last until the next health check
+	assert.Equal(t, endpointOf(up), cli.FullNode().Endpoint)
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// ErrInvalidQuorum is returned by quorum reads when the quorum cannot be met by the pool it is configured for
+var ErrInvalidQuorum = errors.New("invalid quorum config")
+
+// QuorumConfig makes the client read coin state from several nodes of its pool and
+// only accept an answer that enough of them agree on
+type QuorumConfig struct {
+	Nodes    int // nodes queried, the healthiest first, 0 queries every node
+	Required int // nodes that must agree, 0 means a majority of the queried nodes
+
+	// OnDisagreement is called when some queried node failed or answered differently, may be nil
+	OnDisagreement func(op string, report *QuorumReport)
+}
+
+// Validate checks that a pool of poolSize nodes can serve the quorum, i.e. Required <= Nodes <= poolSize
+func (q *QuorumConfig) Validate(poolSize int) error {
+	nodes := q.Nodes
+	if nodes == 0 {
+		nodes = poolSize
+	}
+	if q.Nodes < 0 || nodes > poolSize {
+		return fmt.Errorf("%w: %v nodes queried, the pool has %v", ErrInvalidQuorum, q.Nodes, poolSize)
+	}
+	if q.Required < 0 || q.Required > nodes {
+		return fmt.Errorf("%w: %v nodes required to agree, %v queried", ErrInvalidQuorum, q.Required, nodes)
+	}
+	return nil
+}
+
+// QuorumReport describes how the queried nodes answered a quorum read
+type QuorumReport struct {
+	Required    int
+	Agreed      []string         // endpoints that returned the accepted answer
+	Disagreeing []string         // endpoints that returned another answer
+	Failed      map[string]error // endpoints that returned an error
+}
+
+// QuorumError is returned when no answer reached the required agreement
+type QuorumError struct {
+	Op     string
+	Report *QuorumReport
+}
+
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf("%v: quorum not reached, %v of %v required nodes agreed, disagreeing: %v, failed: %v",
+		e.Op, len(e.Report.Agreed), e.Report.Required, e.Report.Disagreeing, e.Report.Failed)
+}
+
+func (e *QuorumError) Unwrap() error {
+	return rpcerr.ErrQuorumNotReached
+}
+
+// quorumRead runs fn on the queried nodes concurrently and returns the answer whose digest most nodes share
+func quorumRead[T any](
+	pool *NodePool,
+	quorum *QuorumConfig,
+	op string,
+	fn func(*FullNodeService) (T, error),
+	digest func(T) string,
+) (T, *QuorumReport, error) {
+	var result T
+
+	nodes := pool.ranked()
+	if err := quorum.Validate(len(nodes)); err != nil {
+		return result, nil, err
+	}
+	if quorum.Nodes > 0 {
+		nodes = nodes[:quorum.Nodes]
+	}
+
+	required := quorum.Required
+	if required <= 0 {
+		required = len(nodes)/2 + 1
+	}
+
+	type answer struct {
+		value  T
+		digest string
+		err    error
+	}
+	answers := make([]answer, len(nodes))
+	wg := sync.WaitGroup{}
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, service *FullNodeService) {
+			defer wg.Done()
+			value, err := fn(service)
+			answers[i] = answer{value: value, err: err}
+			if err == nil {
+				answers[i].digest = digest(value)
+			}
+		}(i, node.service)
+	}
+	wg.Wait()
+
+	report := &QuorumReport{
+		Required: required,
+		Failed:   map[string]error{},
+	}
+
+	counts := map[string]int{}
+	best := -1
+	for i, answer := range answers {
+		if answer.err != nil {
+			report.Failed[nodes[i].service.Endpoint] = answer.err
+			pool.markFailure(nodes[i], answer.err)
+			continue
+		}
+		counts[answer.digest]++
+		if best < 0 || counts[answer.digest] > counts[answers[best].digest] {
+			best = i
+		}
+	}
+
+	for i, answer := range answers {
+		if answer.err != nil {
+			continue
+		}
+		if answer.digest == answers[best].digest {
+			report.Agreed = append(report.Agreed, nodes[i].service.Endpoint)
+		} else {
+			report.Disagreeing = append(report.Disagreeing, nodes[i].service.Endpoint)
+		}
+	}
+
+	if quorum.OnDisagreement != nil && (len(report.Disagreeing) > 0 || len(report.Failed) > 0) {
+		quorum.OnDisagreement(op, report)
+	}
+
+	if len(report.Agreed) < required {
+		return result, report, &QuorumError{Op: op, Report: report}
+	}
+
+	return answers[best].value, report, nil
+}
+
+// readFullNode runs a coin state read through the quorum when configured, otherwise through the node pool
+func readFullNode[T any](ctx context.Context, cli *Client, op string, fn func(*FullNodeService) (T, error), digest func(T) string) (T, error) {
+	if cli.quorum == nil {
+		return withFullNode(ctx, cli.pool, fn)
+	}
+	result, _, err := quorumRead(cli.pool, cli.quorum, op, fn, digest)
+	return result, err
+}
+
+// unspentDigest identifies a coin set by its sorted coin ids
+func unspentDigest(records []types.CoinRecord) string {
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, record.Coin.ID().String())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// spentDigest identifies the spent status of a coin set
+func spentDigest(records []CoinRecord) string {
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, fmt.Sprintf("%v:%v", record.Coin.ID().String(), record.Spent))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
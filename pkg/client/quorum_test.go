@@ -0,0 +1,95 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+)
+
+func TestQuorumBalance(t *testing.T) {
+	calls := int32(0)
+	first := newFullNodeServer(t, true, 100, 3, &calls)
+	second := newFullNodeServer(t, true, 100, 3, &calls)
+	forked := newFullNodeServer(t, true, 100, 9, &calls)
+
+	reports := []*client.QuorumReport{}
+	cli := client.NewClient(endpointOf(first),
+		client.WithBasePath(""),
+		client.WithFailoverEndpoints(endpointOf(forked), endpointOf(second)),
+		client.WithQuorumConfig(&client.QuorumConfig{
+			Nodes:    3,
+			Required: 2,
+			OnDisagreement: func(op string, report *client.QuorumReport) {
+				reports = append(reports, report)
+			},
+		}),
+	)
+	cli.Pool().CheckInterval = 0
+
+	balance, err := cli.GetBalance(context.Background(), testAddress)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(3), balance)
+	assert.Equal(t, int32(3), calls)
+
+	if assert.Equal(t, 1, len(reports)) {
+		assert.Equal(t, []string{endpointOf(forked)}, reports[0].Disagreeing)
+		assert.ElementsMatch(t, []string{endpointOf(first), endpointOf(second)}, reports[0].Agreed)
+	}
+}
+
+func TestQuorumNotReached(t *testing.T) {
+	calls := int32(0)
+	first := newFullNodeServer(t, true, 100, 3, &calls)
+	forked := newFullNodeServer(t, true, 100, 9, &calls)
+
+	cli := client.NewClient(endpointOf(first),
+		client.WithBasePath(""),
+		client.WithFailoverEndpoints(endpointOf(forked)),
+		client.WithQuorum(0, 2),
+	)
+	cli.Pool().CheckInterval = 0
+
+	_, err := cli.GetBalance(context.Background(), testAddress)
+	assert.True(t, errors.Is(err, rpcerr.ErrQuorumNotReached))
+
+	var quorumErr *client.QuorumError
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.Equal(t, 1, len(quorumErr.Report.Agreed))
+		assert.Equal(t, 1, len(quorumErr.Report.Disagreeing))
+	}
+}
+
+func TestQuorumInvalid(t *testing.T) {
+	calls := int32(0)
+	first := newFullNodeServer(t, true, 100, 3, &calls)
+	second := newFullNodeServer(t, true, 100, 3, &calls)
+
+	for _, quorum := range []*client.QuorumConfig{
+		{Nodes: 3, Required: 3},
+		{Nodes: 2, Required: 3},
+		{Nodes: 0, Required: 3},
+		{Nodes: -1},
+	} {
+		cli := client.NewClient(endpointOf(first),
+			client.WithBasePath(""),
+			client.WithFailoverEndpoints(endpointOf(second)),
+			client.WithQuorumConfig(quorum),
+		)
+		cli.Pool().CheckInterval = 0
+
+		_, err := cli.GetBalance(context.Background(), testAddress)
+		assert.True(t, errors.Is(err, client.ErrInvalidQuorum), err)
+		assert.False(t, errors.Is(err, rpcerr.ErrQuorumNotReached))
+	}
+	assert.Equal(t, int32(0), calls)
+
+	assert.Nil(t, (&client.QuorumConfig{Nodes: 2, Required: 2}).Validate(2))
+	assert.Nil(t, (&client.QuorumConfig{}).Validate(1))
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// RetryPolicy controls how HttpClient resends requests that failed on a transient error
+type RetryPolicy struct {
+	MaxAttempts    int // attempts including the first one, 1 disables retries
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64       // fraction of the backoff that is randomized, between 0 and 1
+	MaxElapsed     time.Duration // no retry is started after this much time, 0 means no limit
+}
+
+// DefaultRetryPolicy returns a policy for WithRetryPolicy, DefaultConfig leaves retries off
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxElapsed:     10 * time.Second,
+	}
+}
+
+// Backoff returns the wait before the retry following the given attempt, starting at 1,
+// it never exceeds MaxBackoff, also with jitter
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff *= 1 - jitter + 2*jitter*rand.Float64() //nolint:gosec
+	}
+
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	return time.Duration(backoff)
+}
+
+// Idempotency tells whether an RPC endpoint may be sent again after a failed attempt
+type Idempotency int
+
+const (
+	// NotRetryable endpoints change wallet state on every call, e.g. send_transaction
+	NotRetryable Idempotency = iota
+	// SafeRetry endpoints only read state
+	SafeRetry
+	// ConditionalRetry endpoints change state but are keyed by their content, push_tx is idempotent by
+	// spend bundle id, so the caller has to accept an "already included" result as success
+	ConditionalRetry
+)
+
+// endpointIdempotency lists endpoints that the get_ prefix rule does not cover
+var endpointIdempotency = map[rpcinterface.Endpoint]Idempotency{
+	"push_tx":                   ConditionalRetry,
+	"healthz":                   SafeRetry,
+	"nft_get_nfts":              SafeRetry,
+	"nft_get_info":              SafeRetry,
+	"nft_get_by_did":            SafeRetry,
+	"nft_count_nfts":            SafeRetry,
+	"nft_get_wallets_with_dids": SafeRetry,
+	"nft_calculate_royalties":   SafeRetry,
+	"nft_set_nft_status":        SafeRetry, // sets a flag, setting it again is a no-op
+	"check_offer_validity":      SafeRetry,
+	"cat_asset_id_to_name":      SafeRetry,
+	"cat_get_asset_id":          SafeRetry,
+	"cat_get_name":              SafeRetry,
+	"verify_signature":          SafeRetry,
+	"pw_status":                 SafeRetry,
+	"vc_get":                    SafeRetry,
+	"vc_get_list":               SafeRetry,
+	"vc_get_proofs_for_root":    SafeRetry,
+	"vc_add_proofs":             SafeRetry, // stores proofs by their content
+	"check_delete_key":          SafeRetry,
+	"select_coins":              SafeRetry,    // only proposes coins, nothing is reserved
+	"log_in":                    SafeRetry,    // logging into the current key again is a no-op
+	"extend_derivation_index":   NotRetryable, // the node rejects an index it already reached
+	"get_next_address":          NotRetryable, // may derive a new address
+	"generate_mnemonic":         NotRetryable,
+}
+
+// EndpointIdempotency classifies an RPC endpoint, get_ endpoints are reads unless listed otherwise
+func EndpointIdempotency(endpoint rpcinterface.Endpoint) Idempotency {
+	if idempotency, ok := endpointIdempotency[endpoint]; ok {
+		return idempotency
+	}
+	if strings.HasPrefix(string(endpoint), "get_") {
+		return SafeRetry
+	}
+	return NotRetryable
+}
+
+// roundTrip sends the request and resends it as allowed by the retry policy and the endpoint idempotency
+func (c *HttpClient) roundTrip(client *http.Client, req *rpcinterface.Request) (*http.Response, error) {
+	policy := c.Retry
+	if policy == nil || policy.MaxAttempts <= 1 || EndpointIdempotency(req.Endpoint) == NotRetryable {
+		return client.Do(req.Request)
+	}
+
+	ctx := req.Request.Context()
+	start := time.Now()
+	httpReq := req.Request
+
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(httpReq)
+		if attempt >= policy.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := policy.Backoff(attempt)
+		if policy.MaxElapsed > 0 && time.Since(start)+wait > policy.MaxElapsed {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		httpReq, err = rewindRequest(req.Request)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewindRequest clones the request with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return transientError(err)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// transientError reports connection level failures that a new attempt may not hit
+func transientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
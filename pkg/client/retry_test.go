@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+var testRetryPolicy = &client.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	Multiplier:     2,
+	Jitter:         0.5,
+	MaxElapsed:     time.Second,
+}
+
+// newFlakyServer drops the connection of the first request and answers the following ones with body
+func newFlakyServer(t *testing.T, body string, attempts *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(attempts, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newRetryClient(server *httptest.Server) *client.Client {
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	return client.NewClient(endpoint,
+		client.WithWalletEndpoint(endpoint),
+		client.WithBasePath(""),
+		client.WithRetryPolicy(testRetryPolicy),
+	)
+}
+
+func TestRetrySafeEndpoint(t *testing.T) {
+	attempts := int32(0)
+	server := newFlakyServer(t, `{"success":true,"blockchain_state":{"sync":{"synced":true}}}`, &attempts)
+
+	synced, err := newRetryClient(server).GetSyncStatus(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, synced)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestNoRetryOnStateChange(t *testing.T) {
+	attempts := int32(0)
+	server := newFlakyServer(t, `{"success":true}`, &attempts)
+
+	_, _, err := newRetryClient(server).Wallet().SendTransaction(context.Background(), &client.SendTransactionOptions{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryPushTXAlreadyIncluded(t *testing.T) {
+	attempts := int32(0)
+	server := newFlakyServer(t,
+		`{"success":false,"status":"FAILED","error":"Failed to include transaction 0x01, error ALREADY_INCLUDING_TRANSACTION"}`,
+		&attempts,
+	)
+
+	txid, err := newRetryClient(server).PushTX(context.Background(), &types.SpendBundle{})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, txid)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &client.RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		backoff := policy.Backoff(2)
+		assert.True(t, backoff >= 100*time.Millisecond && backoff <= 300*time.Millisecond)
+	}
+
+	// jitter never pushes the wait above MaxBackoff
+	for i := 0; i < 20; i++ {
+		backoff := policy.Backoff(10)
+		assert.True(t, backoff <= time.Second)
+	}
+}
+
+func TestEndpointIdempotency(t *testing.T) {
+	assert.Equal(t, client.SafeRetry, client.EndpointIdempotency("get_coin_records_by_puzzle_hash"))
+	assert.Equal(t, client.ConditionalRetry, client.EndpointIdempotency("push_tx"))
+	assert.Equal(t, client.NotRetryable, client.EndpointIdempotency("send_transaction"))
+}
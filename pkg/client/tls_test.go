@@ -0,0 +1,157 @@
+package client_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	chiatls "github.com/chia-network/go-chia-libs/pkg/tls"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+// newChiaRoot generates a CHIA_ROOT certificate layout in a temporary directory
+func newChiaRoot(t *testing.T) string {
+	root := t.TempDir()
+	err := chiatls.GenerateAllCerts(filepath.Join(root, "config", "ssl"))
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// newMutualTLSServer starts a server that only accepts clients signed by the private CA of root
+func newMutualTLSServer(t *testing.T, root string, handler http.Handler) *httptest.Server {
+	ssl := filepath.Join(root, "config", "ssl")
+	keyPair, err := tls.LoadX509KeyPair(
+		filepath.Join(ssl, "full_node", "private_full_node.crt"),
+		filepath.Join(ssl, "full_node", "private_full_node.key"),
+	)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	caPem, err := os.ReadFile(filepath.Join(ssl, "ca", "private_ca.crt"))
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(caPem)
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMutualTLSFromChiaRoot(t *testing.T) {
+	root := newChiaRoot(t)
+	server := newMutualTLSServer(t, root, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/get_blockchain_state", r.URL.Path)
+		_, _ = w.Write([]byte(`{"success":true,"blockchain_state":{"sync":{"synced":true}}}`))
+	}))
+
+	t.Setenv("CHIA_ROOT", root)
+	tlsOption, err := client.WithChiaRootTLS("")
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	cli := client.NewClient(strings.TrimPrefix(server.URL, "https://"), client.WithDefaultPorts(), tlsOption)
+
+	synced, err := cli.GetSyncStatus(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, synced)
+}
+
+func TestNewClientFromChiaRoot(t *testing.T) {
+	root := newChiaRoot(t)
+	server := newMutualTLSServer(t, root, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/get_blockchain_state", r.URL.Path)
+		_, _ = w.Write([]byte(`{"success":true,"blockchain_state":{"sync":{"synced":true}}}`))
+	}))
+
+	cli, err := client.NewClientFromChiaRoot(strings.TrimPrefix(server.URL, "https://"), root)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	synced, err := cli.GetSyncStatus(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, synced)
+
+	_, err = client.NewClientFromChiaRoot("127.0.0.1:8555", t.TempDir())
+	assert.NotNil(t, err)
+}
+
+func TestMutualTLSExplicitPaths(t *testing.T) {
+	root := newChiaRoot(t)
+	server := newMutualTLSServer(t, root, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true,"version":"2.4.0"}`))
+	}))
+
+	ssl := filepath.Join(root, "config", "ssl")
+	service := client.DefaultFullNodeService(strings.TrimPrefix(server.URL, "https://"))
+	service.TLS = client.NewTLSConfig(
+		filepath.Join(ssl, "ca", "private_ca.crt"),
+		filepath.Join(ssl, "wallet", "private_wallet.crt"),
+		filepath.Join(ssl, "wallet", "private_wallet.key"),
+	)
+
+	resp, _, err := service.GetVersion(context.Background(), &client.GetVersionOptions{})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2.4.0", resp.Version)
+}
+
+func TestMutualTLSRejectsForeignCA(t *testing.T) {
+	root := newChiaRoot(t)
+	server := newMutualTLSServer(t, root, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+
+	// certificates from another installation are signed by a different private CA
+	foreign, err := client.TLSConfigFromChiaRoot(newChiaRoot(t), rpcinterface.ServiceFullNode)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	service := client.DefaultFullNodeService(strings.TrimPrefix(server.URL, "https://"))
+	service.TLS = foreign
+
+	_, _, err = service.GetVersion(context.Background(), &client.GetVersionOptions{})
+	assert.NotNil(t, err)
+
+	// swapping the certificates takes effect on the next request
+	service.TLS, err = client.TLSConfigFromChiaRoot(root, rpcinterface.ServiceFullNode)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	_, _, err = service.GetVersion(context.Background(), &client.GetVersionOptions{})
+	assert.Nil(t, err)
+}
+
+func TestTLSConfigFromChiaRootMissing(t *testing.T) {
+	_, err := client.TLSConfigFromChiaRoot(t.TempDir(), rpcinterface.ServiceWallet)
+	assert.NotNil(t, err)
+
+	_, err = client.WithChiaRootTLS(t.TempDir())
+	assert.NotNil(t, err)
+}
@@ -7,6 +7,7 @@ import (
 )
 
 const (
+	// DefaultBasePath is the path prefix used when the node is reached through a plain http proxy
 	DefaultBasePath = "fullnode"
 	DefaultTimeout  = time.Second * 3
 )
@@ -2,8 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
-	"io"
 	"net/http"
 
 	"github.com/samber/mo"
@@ -16,37 +14,25 @@ type WalletService struct {
 	*HttpClient
 }
 
-func DefaultWalletService(endpoint string) *WalletService {
+// NewWalletService creates a wallet RPC service from its connection setting
+func NewWalletService(cfg ServiceConfig) *WalletService {
 	return &WalletService{
 		HttpClient: &HttpClient{
-			Endpoint:    endpoint,
-			BasePath:    DefaultBasePath,
-			Timeout:     DefaultTimeout,
-			serviceType: rpcinterface.ServiceFullNode,
+			Endpoint:    cfg.Endpoint,
+			BasePath:    cfg.BasePath,
+			Timeout:     cfg.Timeout,
+			TLS:         cfg.TLS,
+			Retry:       cfg.Retry,
+			serviceType: rpcinterface.ServiceWallet,
 		},
 	}
 }
 
-// Do sends an RPC request and returns the RPC response.
-func (c *WalletService) Do(req *rpcinterface.Request, v interface{}) (*http.Response, error) {
-	client := http.Client{
-		Timeout: c.Timeout,
-	}
-
-	resp, err := client.Do(req.Request)
-	if err != nil {
-		return nil, err
-	}
-
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-		}
-	}
-
-	return resp, err
+// DefaultWalletService creates a wallet RPC service behind the plain http proxy at endpoint, see DefaultConfig
+func DefaultWalletService(endpoint string) *WalletService {
+	cfg := DefaultConfig(endpoint).Wallet
+	cfg.Endpoint = endpoint
+	return NewWalletService(cfg)
 }
 
 // GetConnections returns connections
@@ -190,6 +176,165 @@ func (s *WalletService) DeleteAllKeys(ctx context.Context) (*DeleteAllKeysRespon
 	return r, resp, nil
 }
 
+// FingerprintOptions options of the key calls taking a fingerprint
+type FingerprintOptions struct {
+	Fingerprint int `json:"fingerprint"`
+}
+
+// LogInResponse response from log_in
+type LogInResponse struct {
+	Response
+	Fingerprint mo.Option[int] `json:"fingerprint"`
+}
+
+// LogIn switches the wallet to the key of a fingerprint, the wallet syncs that key afterwards
+func (s *WalletService) LogIn(ctx context.Context, opts *FingerprintOptions) (*LogInResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "log_in", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &LogInResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetLoggedInFingerprintResponse response from get_logged_in_fingerprint
+type GetLoggedInFingerprintResponse struct {
+	Response
+	Fingerprint mo.Option[int] `json:"fingerprint"` // absent when no key is logged in
+}
+
+// GetLoggedInFingerprint returns the fingerprint of the key the wallet runs with
+func (s *WalletService) GetLoggedInFingerprint(ctx context.Context) (*GetLoggedInFingerprintResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_logged_in_fingerprint", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetLoggedInFingerprintResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// WalletPrivateKey is a key of the keychain with its secrets
+type WalletPrivateKey struct {
+	Fingerprint int               `json:"fingerprint"`
+	SK          string            `json:"sk"`
+	PK          string            `json:"pk"`
+	FarmerPK    string            `json:"farmer_pk"`
+	PoolPK      string            `json:"pool_pk"`
+	Seed        mo.Option[string] `json:"seed"` // the mnemonic, absent for keys imported without one
+}
+
+// GetPrivateKeyResponse response from get_private_key
+type GetPrivateKeyResponse struct {
+	Response
+	PrivateKey mo.Option[WalletPrivateKey] `json:"private_key"`
+}
+
+// GetPrivateKey returns the secrets of a key of the keychain
+func (s *WalletService) GetPrivateKey(ctx context.Context, opts *FingerprintOptions) (*GetPrivateKeyResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_private_key", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetPrivateKeyResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DeleteKeyResponse response from delete_key
+type DeleteKeyResponse struct {
+	Response
+}
+
+// DeleteKey removes a key from the keychain
+func (s *WalletService) DeleteKey(ctx context.Context, opts *FingerprintOptions) (*DeleteKeyResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "delete_key", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DeleteKeyResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CheckDeleteKeyOptions options for check_delete_key
+type CheckDeleteKeyOptions struct {
+	Fingerprint   int    `json:"fingerprint"`
+	MaxPHToSearch uint32 `json:"max_ph_to_search,omitempty"` // addresses searched for the reward targets
+}
+
+// CheckDeleteKeyResponse response from check_delete_key
+type CheckDeleteKeyResponse struct {
+	Response
+	Fingerprint          mo.Option[int]  `json:"fingerprint"`
+	UsedForFarmerRewards mo.Option[bool] `json:"used_for_farmer_rewards"`
+	UsedForPoolRewards   mo.Option[bool] `json:"used_for_pool_rewards"`
+	WalletBalance        mo.Option[bool] `json:"wallet_balance"` // the key holds a balance
+}
+
+// CheckDeleteKey tells whether deleting a key would lose rewards or funds
+func (s *WalletService) CheckDeleteKey(ctx context.Context, opts *CheckDeleteKeyOptions) (*CheckDeleteKeyResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "check_delete_key", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CheckDeleteKeyResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SetWalletResyncOnStartupOptions options for set_wallet_resync_on_startup
+type SetWalletResyncOnStartupOptions struct {
+	Enable bool `json:"enable"`
+}
+
+// SetWalletResyncOnStartupResponse response from set_wallet_resync_on_startup
+type SetWalletResyncOnStartupResponse struct {
+	Response
+}
+
+// SetWalletResyncOnStartup makes the wallet drop its database and sync from scratch on its next start
+func (s *WalletService) SetWalletResyncOnStartup(ctx context.Context, opts *SetWalletResyncOnStartupOptions) (*SetWalletResyncOnStartupResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "set_wallet_resync_on_startup", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SetWalletResyncOnStartupResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // GetNextAddressOptions options for get_next_address endpoint
 type GetNextAddressOptions struct {
 	NewAddress bool   `json:"new_address"`
@@ -227,6 +372,22 @@ type GetWalletSyncStatusResponse struct {
 	Syncing            mo.Option[bool] `json:"syncing"`
 }
 
+// GetSyncStatus wallet rpc -> get_sync_status
+func (s *WalletService) GetSyncStatus(ctx context.Context) (*GetWalletSyncStatusResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_sync_status", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetWalletSyncStatusResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // GetWalletHeightInfoResponse response for get_height_info on wallet
 type GetWalletHeightInfoResponse struct {
 	Response
@@ -334,10 +495,11 @@ func (s *WalletService) GetTransactionCount(ctx context.Context, opts *GetWallet
 
 // GetWalletTransactionsOptions options for get wallet transactions
 type GetWalletTransactionsOptions struct {
-	WalletID  uint32 `json:"wallet_id"`
-	Start     *int   `json:"start,omitempty"`
-	End       *int   `json:"end,omitempty"`
-	ToAddress string `json:"to_address,omitempty"`
+	WalletID   uint32                 `json:"wallet_id"`
+	Start      *int                   `json:"start,omitempty"`
+	End        *int                   `json:"end,omitempty"`
+	ToAddress  string                 `json:"to_address,omitempty"`
+	TypeFilter *TransactionTypeFilter `json:"type_filter,omitempty"`
 }
 
 // GetWalletTransactionsResponse response for get_wallet_transactions
@@ -394,12 +556,13 @@ func (s *WalletService) GetTransaction(ctx context.Context, opts *GetWalletTrans
 
 // SendTransactionOptions represents the options for send_transaction
 type SendTransactionOptions struct {
-	WalletID uint32        `json:"wallet_id"`
-	Amount   uint64        `json:"amount"`
-	Address  string        `json:"address"`
-	Memos    []types.Bytes `json:"memos,omitempty"`
-	Fee      uint64        `json:"fee"`
-	Coins    []types.Coin  `json:"coins,omitempty"`
+	WalletID        uint32            `json:"wallet_id"`
+	Amount          uint64            `json:"amount"`
+	Address         string            `json:"address"`
+	Memos           []types.Bytes     `json:"memos,omitempty"`
+	Fee             uint64            `json:"fee"`
+	Coins           []types.Coin      `json:"coins,omitempty"`
+	PuzzleDecorator []PuzzleDecorator `json:"puzzle_decorator,omitempty"`
 }
 
 // SendTransactionResponse represents the response from send_transaction
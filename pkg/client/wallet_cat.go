@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+var ErrNoAssetWallet = errors.New("no wallet for asset")
+
+// noAssetWalletMessage is how cat_asset_id_to_name reports an asset that neither a wallet nor the
+// default CAT list knows
+const noAssetWalletMessage = "The asset ID specified does not belong to a wallet"
+
+// CAT wallet creation modes
+const (
+	CATModeExisting = "existing" // track a CAT issued elsewhere
+	CATModeNew      = "new"      // issue a new CAT with a single issuance TAIL, sent with the test flag a stock wallet requires
+)
+
+// CreateCATWalletOptions options for creating a CAT wallet through create_new_wallet.
+// AssetID is used by CATModeExisting, Amount and Fee by CATModeNew
+type CreateCATWalletOptions struct {
+	Mode    string `json:"mode"`
+	AssetID string `json:"asset_id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Amount  uint64 `json:"amount,omitempty"`
+	Fee     uint64 `json:"fee"` // not required
+}
+
+// createCATWalletRequest is the create_new_wallet body of CreateCATWallet
+type createCATWalletRequest struct {
+	WalletType string `json:"wallet_type"`
+	Test       bool   `json:"test,omitempty"` // the wallet refuses CATModeNew without it
+	CreateCATWalletOptions
+}
+
+// CreateCATWalletResponse response from create_new_wallet for a CAT wallet
+type CreateCATWalletResponse struct {
+	Response
+	Type     mo.Option[types.WalletType] `json:"type"`
+	AssetID  mo.Option[string]           `json:"asset_id"`
+	WalletID mo.Option[uint32]           `json:"wallet_id"`
+}
+
+// CreateCATWallet creates a wallet for an existing CAT or issues a new one
+func (s *WalletService) CreateCATWallet(ctx context.Context, opts *CreateCATWalletOptions) (*CreateCATWalletResponse, *http.Response, error) {
+	create := &createCATWalletRequest{WalletType: "cat_wallet"}
+	if opts != nil {
+		create.CreateCATWalletOptions = *opts
+	}
+	create.Test = create.Mode == CATModeNew
+
+	request, err := s.NewRequest(ctx, "create_new_wallet", create)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateCATWalletResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CATAssetIDToNameOptions options for cat_asset_id_to_name
+type CATAssetIDToNameOptions struct {
+	AssetID string `json:"asset_id"`
+}
+
+// CATAssetIDToNameResponse response from cat_asset_id_to_name, WalletID is absent when no wallet tracks the asset
+type CATAssetIDToNameResponse struct {
+	Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+	Name     mo.Option[string] `json:"name"`
+}
+
+// CATAssetIDToName returns the name of a CAT and the wallet tracking it
+func (s *WalletService) CATAssetIDToName(ctx context.Context, opts *CATAssetIDToNameOptions) (*CATAssetIDToNameResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cat_asset_id_to_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CATAssetIDToNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CATWalletOptions options of the CAT calls taking only a wallet id
+type CATWalletOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CATGetAssetIDResponse response from cat_get_asset_id
+type CATGetAssetIDResponse struct {
+	Response
+	AssetID  mo.Option[string] `json:"asset_id"`
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+}
+
+// CATGetAssetID returns the asset id of a CAT wallet
+func (s *WalletService) CATGetAssetID(ctx context.Context, opts *CATWalletOptions) (*CATGetAssetIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cat_get_asset_id", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CATGetAssetIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CATSetNameOptions options for cat_set_name
+type CATSetNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// CATSetNameResponse response from cat_set_name
+type CATSetNameResponse struct {
+	Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+}
+
+// CATSetName renames a CAT wallet
+func (s *WalletService) CATSetName(ctx context.Context, opts *CATSetNameOptions) (*CATSetNameResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cat_set_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CATSetNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CATGetNameResponse response from cat_get_name
+type CATGetNameResponse struct {
+	Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+	Name     mo.Option[string] `json:"name"`
+}
+
+// CATGetName returns the name of a CAT wallet
+func (s *WalletService) CATGetName(ctx context.Context, opts *CATWalletOptions) (*CATGetNameResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cat_get_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CATGetNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// StrayCAT is a CAT the wallet received without having a wallet for it
+type StrayCAT struct {
+	AssetID          string        `json:"asset_id"`
+	Name             string        `json:"name"`
+	FirstSeenHeight  uint32        `json:"first_seen_height"`
+	SenderPuzzleHash types.Bytes32 `json:"sender_puzzle_hash"`
+}
+
+// GetStrayCATsResponse response from get_stray_cats
+type GetStrayCATsResponse struct {
+	Response
+	StrayCATs mo.Option[[]StrayCAT] `json:"stray_cats"`
+}
+
+// GetStrayCATs returns the CATs received without a wallet, create a wallet with CATModeExisting to spend them
+func (s *WalletService) GetStrayCATs(ctx context.Context) (*GetStrayCATsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_stray_cats", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetStrayCATsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// WalletIDForAsset returns the id of the wallet tracking a CAT, as needed by CatSpendOptions,
+// ErrNoAssetWallet when the wallet does not track it
+func (cli *Client) WalletIDForAsset(ctx context.Context, assetID string) (uint32, error) {
+	assetID = strings.TrimPrefix(strings.ToLower(assetID), "0x")
+
+	resp, httpResp, err := cli.walletService.CATAssetIDToName(ctx, &CATAssetIDToNameOptions{
+		AssetID: assetID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return 0, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return 0, rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		if strings.Contains(*resp.Error.ToPointer(), noAssetWalletMessage) {
+			return 0, fmt.Errorf("%w %v", ErrNoAssetWallet, assetID)
+		}
+		return 0, rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	if resp.WalletID.ToPointer() == nil {
+		return 0, fmt.Errorf("%w %v", ErrNoAssetWallet, assetID)
+	}
+
+	return resp.WalletID.MustGet(), nil
+}
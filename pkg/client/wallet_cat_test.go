@@ -0,0 +1,96 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestWalletIDForAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts := client.CATAssetIDToNameOptions{}
+		_ = json.NewDecoder(r.Body).Decode(&opts)
+		if opts.AssetID == testAssetID {
+			_, _ = w.Write([]byte(`{"success":true,"wallet_id":3,"name":"USDS"}`))
+			return
+		}
+		if opts.AssetID == "cd" {
+			_, _ = w.Write([]byte(`{"success":false,"error":"The asset ID specified does not belong to a wallet"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"wallet_id":null,"name":"Unknown"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	walletID, err := cli.WalletIDForAsset(context.Background(), "0x"+testAssetID)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(3), walletID)
+
+	_, err = cli.WalletIDForAsset(context.Background(), "ab")
+	assert.True(t, errors.Is(err, client.ErrNoAssetWallet))
+
+	_, err = cli.WalletIDForAsset(context.Background(), "0xCD")
+	assert.True(t, errors.Is(err, client.ErrNoAssetWallet))
+}
+
+func TestCreateCATWallet(t *testing.T) {
+	sent := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_, _ = w.Write([]byte(`{"success":true,"type":6,"asset_id":"` + testAssetID + `","wallet_id":3}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	resp, _, err := service.CreateCATWallet(context.Background(), &client.CreateCATWalletOptions{
+		Mode:    client.CATModeExisting,
+		AssetID: testAssetID,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(3), resp.WalletID.OrEmpty())
+	assert.Equal(t, "cat_wallet", sent["wallet_type"])
+	assert.Equal(t, "existing", sent["mode"])
+	assert.Equal(t, testAssetID, sent["asset_id"])
+	assert.NotContains(t, sent, "test")
+
+	sent = map[string]interface{}{}
+	_, _, err = service.CreateCATWallet(context.Background(), &client.CreateCATWalletOptions{
+		Mode:   client.CATModeNew,
+		Name:   "USDS",
+		Amount: 1000,
+		Fee:    5,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "cat_wallet", sent["wallet_type"])
+	assert.Equal(t, "new", sent["mode"])
+	assert.Equal(t, true, sent["test"])
+	assert.Equal(t, float64(1000), sent["amount"])
+	assert.Equal(t, float64(5), sent["fee"])
+	assert.NotContains(t, sent, "asset_id")
+
+	sent = map[string]interface{}{}
+	_, _, err = service.CreateCATWallet(context.Background(), nil)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "cat_wallet", sent["wallet_type"])
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// DecoratorClawback is the puzzle decorator of clawback payments
+const DecoratorClawback = "CLAWBACK"
+
+// Clawback transaction types, the go-chia-libs TransactionType constants stop before them
+const (
+	TransactionTypeIncomingClawbackReceive types.TransactionType = 6 // a clawback payment the wallet can claim
+	TransactionTypeIncomingClawbackSend    types.TransactionType = 7 // a clawback payment the wallet can claw back
+	TransactionTypeOutgoingClawback        types.TransactionType = 8 // the spend claiming or clawing back a payment
+)
+
+// clawbackPageSize is the page size PendingClawbacks reads coin records and transactions with
+const clawbackPageSize = 50
+
+// PuzzleDecorator wraps the puzzle of a payment of send_transaction
+type PuzzleDecorator struct {
+	Decorator        string `json:"decorator"`
+	ClawbackTimelock uint64 `json:"clawback_timelock,omitempty"` // seconds
+}
+
+// ClawbackDecorator makes a payment claimable by the recipient after timelock, the sender can claw it back until claimed
+func ClawbackDecorator(timelock time.Duration) PuzzleDecorator {
+	return PuzzleDecorator{
+		Decorator:        DecoratorClawback,
+		ClawbackTimelock: uint64(timelock / time.Second),
+	}
+}
+
+// TransactionTypeFilter includes or excludes transactions of get_transactions by type
+type TransactionTypeFilter struct {
+	Values []types.TransactionType `json:"values"`
+	Mode   uint8                   `json:"mode"`
+}
+
+// ClawbackMetadata is the metadata of a clawback coin record
+type ClawbackMetadata struct {
+	TimeLock            uint64        `json:"time_lock"` // seconds
+	SenderPuzzleHash    types.Bytes32 `json:"sender_puzzle_hash"`
+	RecipientPuzzleHash types.Bytes32 `json:"recipient_puzzle_hash"`
+}
+
+// ClawbackMetadata decodes the metadata of a clawback coin record
+func (r *WalletCoinRecord) ClawbackMetadata() (*ClawbackMetadata, error) {
+	if r.Type != CoinTypeClawback {
+		return nil, fmt.Errorf("coin %v is not a clawback coin", r.ID)
+	}
+
+	metadata := &ClawbackMetadata{}
+	if err := json.Unmarshal(r.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("invalid clawback metadata of %v: %v", r.ID, err)
+	}
+	return metadata, nil
+}
+
+// SpendClawbackCoinsOptions options for spend_clawback_coins, the wallet claims the coins it received
+// and claws back the coins it sent
+type SpendClawbackCoinsOptions struct {
+	CoinIDs   []string `json:"coin_ids"`
+	Fee       uint64   `json:"fee"`                  // not required
+	BatchSize *uint32  `json:"batch_size,omitempty"` // coins per spend bundle
+	Force     bool     `json:"force"`                // also spend coins the wallet marked as pending
+}
+
+// SpendClawbackCoinsResponse response from spend_clawback_coins
+type SpendClawbackCoinsResponse struct {
+	Response
+	TransactionIDs mo.Option[[]string]                  `json:"transaction_ids"`
+	Transactions   mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// SpendClawbackCoins claims or claws back clawback coins
+func (s *WalletService) SpendClawbackCoins(ctx context.Context, opts *SpendClawbackCoinsOptions) (*SpendClawbackCoinsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "spend_clawback_coins", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SpendClawbackCoinsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// ClawbackCoin is an unspent clawback coin of the wallet
+type ClawbackCoin struct {
+	Record   WalletCoinRecord
+	Metadata ClawbackMetadata
+	Incoming bool // claimable by the wallet after Metadata.TimeLock, otherwise sent by the wallet and revocable
+}
+
+// PendingClawbacks returns the unspent clawback coins of the standard wallet, pass their ids to
+// SpendClawbackCoins to claim the incoming ones and claw back the others
+func (cli *Client) PendingClawbacks(ctx context.Context) ([]ClawbackCoin, error) {
+	coinType := CoinTypeClawback
+	records := []WalletCoinRecord{}
+	for offset := uint32(0); ; offset += clawbackPageSize {
+		resp, httpResp, err := cli.walletService.GetCoinRecords(ctx, &GetCoinRecordsOptions{
+			Offset:     offset,
+			Limit:      clawbackPageSize,
+			CoinType:   &coinType,
+			SpentRange: UnspentRange,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return nil, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return nil, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		page := resp.CoinRecords.OrEmpty()
+		records = append(records, page...)
+		if len(page) < clawbackPageSize {
+			break
+		}
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// both sides track the coin as a clawback coin, only the transaction type tells the recipient apart
+	incoming := map[types.Bytes32]bool{}
+	for start := 0; ; start += clawbackPageSize {
+		end := start + clawbackPageSize
+		resp, httpResp, err := cli.walletService.GetTransactions(ctx, &GetWalletTransactionsOptions{
+			WalletID: standardWalletID,
+			Start:    &start,
+			End:      &end,
+			TypeFilter: &TransactionTypeFilter{
+				Values: []types.TransactionType{TransactionTypeIncomingClawbackReceive},
+				Mode:   FilterModeInclude,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return nil, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return nil, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		page := resp.Transactions.OrEmpty()
+		for _, tx := range page {
+			for _, coin := range tx.Additions {
+				incoming[coin.ID()] = true
+			}
+		}
+		if len(page) < clawbackPageSize {
+			break
+		}
+	}
+
+	coins := []ClawbackCoin{}
+	for _, record := range records {
+		metadata, err := record.ClawbackMetadata()
+		if err != nil {
+			return nil, err
+		}
+		coin := record.Coin()
+		coins = append(coins, ClawbackCoin{
+			Record:   record,
+			Metadata: *metadata,
+			Incoming: incoming[coin.ID()],
+		})
+	}
+	return coins, nil
+}
@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestSendClawbackTransaction(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		_, _ = w.Write([]byte(`{"success":true,"transaction_ids":["0x01"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	_, _, err := service.SendTransaction(context.Background(), &client.SendTransactionOptions{
+		WalletID:        1,
+		Amount:          1000,
+		Address:         testAddress,
+		PuzzleDecorator: []client.PuzzleDecorator{client.ClawbackDecorator(time.Hour)},
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"decorator":         "CLAWBACK",
+		"clawback_timelock": float64(3600),
+	}}, bodies["/send_transaction"]["puzzle_decorator"])
+
+	resp, _, err := service.SpendClawbackCoins(context.Background(), &client.SpendClawbackCoinsOptions{
+		CoinIDs: []string{"0x01"},
+		Fee:     5,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"0x01"}, resp.TransactionIDs.MustGet())
+	assert.NotContains(t, bodies["/spend_clawback_coins"], "batch_size")
+}
+
+func TestPendingClawbacks(t *testing.T) {
+	const parent = "0x0202020202020202020202020202020202020202020202020202020202020202"
+	const merkle = "0x0303030303030303030303030303030303030303030303030303030303030303"
+	record := func(amount uint64) string {
+		return fmt.Sprintf(`{"id":"0x%v","amount":%v,"type":1,"coinbase":false,
+			"puzzle_hash":"%v","parent_coin_info":"%v","wallet_identifier":{"id":1,"type":0},
+			"confirmed_height":12,"spent_height":0,
+			"metadata":{"time_lock":3600,
+				"sender_puzzle_hash":"0x0404040404040404040404040404040404040404040404040404040404040404",
+				"recipient_puzzle_hash":"0x0505050505050505050505050505050505050505050505050505050505050505"}}`,
+			amount, amount, merkle, parent)
+	}
+
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		switch r.URL.Path {
+		case "/get_coin_records":
+			_, _ = fmt.Fprintf(w, `{"success":true,"coin_records":[%v,%v]}`, record(100), record(200))
+		case "/get_transactions":
+			_, _ = fmt.Fprintf(w, `{"success":true,"wallet_id":1,"transactions":[{"type":6,
+				"additions":[{"parent_coin_info":"%v","puzzle_hash":"%v","amount":200}],"removals":[]}]}`, parent, merkle)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	coins, err := cli.PendingClawbacks(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(coins)) {
+		assert.False(t, coins[0].Incoming)
+		assert.True(t, coins[1].Incoming)
+		assert.Equal(t, uint64(3600), coins[1].Metadata.TimeLock)
+	}
+
+	assert.Equal(t, float64(client.CoinTypeClawback), bodies["/get_coin_records"]["coin_type"])
+	assert.Equal(t, map[string]interface{}{
+		"values": []interface{}{float64(6)},
+		"mode":   float64(client.FilterModeInclude),
+	}, bodies["/get_transactions"]["type_filter"])
+}
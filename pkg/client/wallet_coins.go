@@ -0,0 +1,344 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// MaxCoinsPerCoinOp is the most coins combine_coins and split_coins accept in one transaction,
+// both reject a larger number_of_coins in chia/rpc/wallet_rpc_api.py
+const MaxCoinsPerCoinOp = 500
+
+var (
+	ErrCoinTooSmall = errors.New("no coin large enough to split")
+	ErrCoinsPending = errors.New("wallet has unconfirmed coins")
+)
+
+// Filter modes of get_coin_records value filters
+const (
+	FilterModeInclude uint8 = 1
+	FilterModeExclude uint8 = 2
+)
+
+// Coin types of wallet coin records
+const (
+	CoinTypeNormal   uint8 = 0
+	CoinTypeClawback uint8 = 1
+)
+
+// Coin record orders of get_coin_records
+const (
+	CoinRecordOrderConfirmedHeight uint8 = 1
+	CoinRecordOrderSpentHeight     uint8 = 2
+)
+
+// SelectCoinsOptions options for select_coins
+type SelectCoinsOptions struct {
+	WalletID            uint32   `json:"wallet_id"`
+	Amount              uint64   `json:"amount"`
+	MinCoinAmount       *uint64  `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount       *uint64  `json:"max_coin_amount,omitempty"`
+	ExcludedCoinAmounts []uint64 `json:"excluded_coin_amounts,omitempty"`
+	ExcludedCoinIDs     []string `json:"excluded_coin_ids,omitempty"`
+}
+
+// SelectCoinsResponse response from select_coins
+type SelectCoinsResponse struct {
+	Response
+	Coins mo.Option[[]types.Coin] `json:"coins"`
+}
+
+// SelectCoins returns the coins the wallet would spend to send amount, it does not reserve them
+func (s *WalletService) SelectCoins(ctx context.Context, opts *SelectCoinsOptions) (*SelectCoinsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "select_coins", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SelectCoinsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HashFilter includes or excludes records by hex hashes
+type HashFilter struct {
+	Values []string `json:"values"`
+	Mode   uint8    `json:"mode"`
+}
+
+// AmountFilter includes or excludes records by exact amounts
+type AmountFilter struct {
+	Values []uint64 `json:"values"`
+	Mode   uint8    `json:"mode"`
+}
+
+// UInt64Range is an inclusive range
+type UInt64Range struct {
+	Start uint64 `json:"start"`
+	Stop  uint64 `json:"stop"`
+}
+
+// UInt32Range is an inclusive range of heights
+type UInt32Range struct {
+	Start uint32 `json:"start"`
+	Stop  uint32 `json:"stop"`
+}
+
+// UnspentRange is a SpentRange matching unspent records, whose spent height is 0
+var UnspentRange = &UInt32Range{Start: 0, Stop: 0}
+
+// SpentRange is a SpentRange matching spent records
+var SpentRange = &UInt32Range{Start: 1, Stop: 1<<32 - 1}
+
+// GetCoinRecordsOptions options for get_coin_records, unset filters match every record
+type GetCoinRecordsOptions struct {
+	Offset             uint32        `json:"offset"`
+	Limit              uint32        `json:"limit,omitempty"` // the wallet default is used when 0
+	WalletID           *uint32       `json:"wallet_id,omitempty"`
+	WalletType         *uint8        `json:"wallet_type,omitempty"`
+	CoinType           *uint8        `json:"coin_type,omitempty"`
+	CoinIDFilter       *HashFilter   `json:"coin_id_filter,omitempty"`
+	PuzzleHashFilter   *HashFilter   `json:"puzzle_hash_filter,omitempty"`
+	ParentCoinIDFilter *HashFilter   `json:"parent_coin_id_filter,omitempty"`
+	AmountFilter       *AmountFilter `json:"amount_filter,omitempty"`
+	AmountRange        *UInt64Range  `json:"amount_range,omitempty"`
+	ConfirmedRange     *UInt32Range  `json:"confirmed_range,omitempty"`
+	SpentRange         *UInt32Range  `json:"spent_range,omitempty"`
+	Order              uint8         `json:"order,omitempty"` // CoinRecordOrderConfirmedHeight when 0
+	Reverse            bool          `json:"reverse"`
+	IncludeTotalCount  bool          `json:"include_total_count"`
+}
+
+// WalletIdentifier identifies the wallet a coin belongs to
+type WalletIdentifier struct {
+	ID   uint32 `json:"id"`
+	Type uint8  `json:"type"`
+}
+
+// WalletCoinRecord is a coin as tracked by the wallet, SpentHeight is 0 while unspent
+type WalletCoinRecord struct {
+	ID               string           `json:"id"`
+	Amount           uint64           `json:"amount"`
+	PuzzleHash       types.Bytes32    `json:"puzzle_hash"`
+	ParentCoinInfo   types.Bytes32    `json:"parent_coin_info"`
+	Type             uint8            `json:"type"`
+	WalletIdentifier WalletIdentifier `json:"wallet_identifier"`
+	Metadata         json.RawMessage  `json:"metadata,omitempty"`
+	ConfirmedHeight  uint32           `json:"confirmed_height"`
+	SpentHeight      uint32           `json:"spent_height"`
+	Coinbase         bool             `json:"coinbase"`
+}
+
+// Coin returns the coin of the record
+func (r *WalletCoinRecord) Coin() types.Coin {
+	return types.Coin{
+		ParentCoinInfo: r.ParentCoinInfo,
+		PuzzleHash:     r.PuzzleHash,
+		Amount:         r.Amount,
+	}
+}
+
+// GetCoinRecordsResponse response from get_coin_records, TotalCount is set with IncludeTotalCount
+type GetCoinRecordsResponse struct {
+	Response
+	CoinRecords mo.Option[[]WalletCoinRecord] `json:"coin_records"`
+	TotalCount  mo.Option[uint32]             `json:"total_count"`
+}
+
+// GetCoinRecords returns a page of the coin records the wallet tracks
+func (s *WalletService) GetCoinRecords(ctx context.Context, opts *GetCoinRecordsOptions) (*GetCoinRecordsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_coin_records", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetCoinRecordsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CombineCoinsOptions options for combine_coins, the wallet picks the coins unless TargetCoinIDs is set
+type CombineCoinsOptions struct {
+	WalletID         uint32   `json:"wallet_id"`
+	NumberOfCoins    uint32   `json:"number_of_coins"`
+	LargestFirst     bool     `json:"largest_first"`
+	TargetCoinIDs    []string `json:"target_coin_ids,omitempty"`
+	TargetCoinAmount *uint64  `json:"target_coin_amount,omitempty"` // the whole sum of the combined coins when nil
+	Fee              uint64   `json:"fee"`                          // not required
+}
+
+// CoinTransactionsResponse response from combine_coins and split_coins
+type CoinTransactionsResponse struct {
+	Response
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// CombineCoins spends several coins of a wallet into one
+func (s *WalletService) CombineCoins(ctx context.Context, opts *CombineCoinsOptions) (*CoinTransactionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "combine_coins", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CoinTransactionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SplitCoinsOptions options for split_coins, the remainder of the target coin goes back to the wallet as change
+type SplitCoinsOptions struct {
+	WalletID      uint32 `json:"wallet_id"`
+	NumberOfCoins uint32 `json:"number_of_coins"`
+	AmountPerCoin uint64 `json:"amount_per_coin"`
+	TargetCoinID  string `json:"target_coin_id"`
+	Fee           uint64 `json:"fee"` // not required
+}
+
+// SplitCoins spends one coin of a wallet into NumberOfCoins coins of AmountPerCoin
+func (s *WalletService) SplitCoins(ctx context.Context, opts *SplitCoinsOptions) (*CoinTransactionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "split_coins", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CoinTransactionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CoinBand is the number of spendable coins a wallet should hold
+type CoinBand struct {
+	Min int
+	Max int
+	Fee uint64 // fee of the combine or split transaction
+}
+
+// KeepCoinBand combines the smallest coins of a wallet when it holds more than band.Max spendable coins,
+// so that it ends up with band.Max coins, or splits its largest coin when it holds fewer than band.Min, so that
+// it ends up with band.Min coins.
+// The split sends one coin less than it needs and leaves the rest of the split coin as change, which makes
+// the last coin. Only confirmed coins are counted, while the wallet has unconfirmed coins, including those of
+// a previous call, it returns ErrCoinsPending. It returns the transactions sent, none when the wallet is
+// already inside the band
+func (cli *Client) KeepCoinBand(ctx context.Context, walletID uint32, band CoinBand) ([]types.TransactionRecord, error) {
+	if band.Min < 0 || band.Max < band.Min || band.Max < 1 {
+		return nil, fmt.Errorf("invalid coin band %v-%v", band.Min, band.Max)
+	}
+
+	resp, httpResp, err := cli.walletService.GetSpendableCoins(ctx, &GetSpendableCoinsOptions{
+		WalletID: walletID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return nil, rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return nil, rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	if len(resp.UnconfirmedAdditions.OrEmpty()) > 0 || len(resp.UnconfirmedRemovals.OrEmpty()) > 0 {
+		return nil, ErrCoinsPending
+	}
+
+	coins := resp.ConfirmedRecords.OrEmpty()
+	count := len(coins)
+
+	var txs *CoinTransactionsResponse
+	switch {
+	case count > band.Max:
+		// combining n coins into one removes n-1 of them
+		combine := count - band.Max + 1
+		if combine > len(coins) {
+			combine = len(coins)
+		}
+		if combine > MaxCoinsPerCoinOp {
+			combine = MaxCoinsPerCoinOp
+		}
+		if combine < 2 {
+			return nil, nil
+		}
+		txs, httpResp, err = cli.walletService.CombineCoins(ctx, &CombineCoinsOptions{
+			WalletID:      walletID,
+			NumberOfCoins: uint32(combine),
+			LargestFirst:  false,
+			Fee:           band.Fee,
+		})
+	case count < band.Min:
+		if len(coins) == 0 {
+			return nil, ErrCoinTooSmall
+		}
+		sort.Slice(coins, func(i, j int) bool {
+			return coins[i].Coin.Amount > coins[j].Coin.Amount
+		})
+		largest := coins[0].Coin
+
+		// the split coin is spent, so it has to make one more coin than is missing. The wallet returns what
+		// the outputs leave of it as change, so one output less is sent and the change is at least as large
+		split := band.Min - count + 1
+		if split > MaxCoinsPerCoinOp+1 {
+			split = MaxCoinsPerCoinOp + 1
+		}
+		if largest.Amount <= band.Fee || (largest.Amount-band.Fee)/uint64(split) == 0 {
+			return nil, fmt.Errorf("%w, largest coin %v", ErrCoinTooSmall, largest.Amount)
+		}
+		txs, httpResp, err = cli.walletService.SplitCoins(ctx, &SplitCoinsOptions{
+			WalletID:      walletID,
+			NumberOfCoins: uint32(split - 1),
+			AmountPerCoin: (largest.Amount - band.Fee) / uint64(split),
+			TargetCoinID:  largest.ID().String(),
+			Fee:           band.Fee,
+		})
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if txs == nil {
+		return nil, rpcerr.ErrNoResponse
+	}
+
+	if txs.Error.ToPointer() != nil {
+		return nil, rpcerr.New(*txs.Error.ToPointer())
+	}
+
+	return txs.Transactions.OrEmpty(), nil
+}
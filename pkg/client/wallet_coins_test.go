@@ -0,0 +1,155 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+// newCoinWalletServer serves get_spendable_coins with confirmed coins of the given amounts and pending
+// additions of the pending amounts, and records the other requests
+func newCoinWalletServer(t *testing.T, amounts, pending []uint64, bodies map[string]map[string]interface{}) *httptest.Server {
+	records := func(amounts []uint64, offset int) string {
+		encoded := []string{}
+		for i, amount := range amounts {
+			encoded = append(encoded, fmt.Sprintf(`{"coin":{"parent_coin_info":"0x%064x","puzzle_hash":"0x%064x","amount":%v},
+				"confirmed_block_index":10,"spent_block_index":0,"spent":false,"coinbase":false,"timestamp":0}`,
+				offset+i+1, 1, amount))
+		}
+		return strings.Join(encoded, ",")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		if r.URL.Path == "/get_spendable_coins" {
+			_, _ = fmt.Fprintf(w, `{"success":true,"confirmed_records":[%v],"unconfirmed_removals":[],"unconfirmed_additions":[%v]}`,
+				records(amounts, 0), records(pending, len(amounts)))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"transactions":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestKeepCoinBand(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := newCoinWalletServer(t, []uint64{100, 1, 2, 3, 4, 5}, nil, bodies)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	_, err := cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 2, Max: 4})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	combine := bodies["/combine_coins"]
+	assert.Equal(t, float64(3), combine["number_of_coins"])
+	assert.Equal(t, false, combine["largest_first"])
+
+	bodies = map[string]map[string]interface{}{}
+	server = newCoinWalletServer(t, []uint64{7, 1000}, nil, bodies)
+	cli = client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	_, err = cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 5, Max: 10, Fee: 10})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	// 3 outputs of 247 and 249 change replace the coin of 1000, 5 coins with the coin of 7
+	split := bodies["/split_coins"]
+	assert.Equal(t, float64(3), split["number_of_coins"])
+	assert.Equal(t, float64(247), split["amount_per_coin"])
+	assert.Equal(t, float64(10), split["fee"])
+	assert.True(t, strings.HasPrefix(split["target_coin_id"].(string), "0x"))
+
+	bodies = map[string]map[string]interface{}{}
+	server = newCoinWalletServer(t, []uint64{1, 2}, nil, bodies)
+	cli = client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	txs, err := cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 1, Max: 3})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Nil(t, txs)
+	assert.Equal(t, 1, len(bodies))
+
+	_, err = cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 10, Max: 20})
+	assert.ErrorIs(t, err, client.ErrCoinTooSmall)
+}
+
+func TestKeepCoinBandEdge(t *testing.T) {
+	// a band of exactly 4 coins: 3 outputs of 250 and the change of 250 make 4 coins, not 5
+	bodies := map[string]map[string]interface{}{}
+	server := newCoinWalletServer(t, []uint64{1000}, nil, bodies)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	_, err := cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 4, Max: 4})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	split := bodies["/split_coins"]
+	assert.Equal(t, float64(3), split["number_of_coins"])
+	assert.Equal(t, float64(250), split["amount_per_coin"])
+
+	// pending coins of the split are not counted, nothing is sent until they confirm
+	bodies = map[string]map[string]interface{}{}
+	server = newCoinWalletServer(t, nil, []uint64{250, 250, 250, 250}, bodies)
+	cli = client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	_, err = cli.KeepCoinBand(context.Background(), 1, client.CoinBand{Min: 4, Max: 4})
+	assert.ErrorIs(t, err, client.ErrCoinsPending)
+	assert.Equal(t, 1, len(bodies))
+}
+
+func TestGetCoinRecords(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		_, _ = w.Write([]byte(`{"success":true,"total_count":7,"coin_records":[{
+			"id":"0xaa","amount":5,"type":0,"coinbase":false,"metadata":null,
+			"puzzle_hash":"0x0101010101010101010101010101010101010101010101010101010101010101",
+			"parent_coin_info":"0x0202020202020202020202020202020202020202020202020202020202020202",
+			"wallet_identifier":{"id":1,"type":0},"confirmed_height":12,"spent_height":0
+		}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	walletID := uint32(1)
+	resp, _, err := service.GetCoinRecords(context.Background(), &client.GetCoinRecordsOptions{
+		Limit:             50,
+		WalletID:          &walletID,
+		AmountRange:       &client.UInt64Range{Start: 1, Stop: 10},
+		SpentRange:        client.UnspentRange,
+		IncludeTotalCount: true,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(7), resp.TotalCount.MustGet())
+	records := resp.CoinRecords.MustGet()
+	if assert.Equal(t, 1, len(records)) {
+		coin := records[0].Coin()
+		assert.Equal(t, uint64(5), coin.Amount)
+		assert.Equal(t, uint32(12), records[0].ConfirmedHeight)
+	}
+
+	sent := bodies["/get_coin_records"]
+	assert.Equal(t, map[string]interface{}{"start": float64(0), "stop": float64(0)}, sent["spent_range"])
+	assert.Equal(t, float64(50), sent["limit"])
+	assert.NotContains(t, sent, "coin_id_filter")
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/puzzlehash"
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// MaxDerivationIndexDelta is how far extend_derivation_index may move the index in one call,
+// MAX_DERIVATION_INDEX_DELTA in chia/rpc/wallet_rpc_api.py
+const MaxDerivationIndexDelta = 1000
+
+// GetCurrentDerivationIndexResponse response from get_current_derivation_index, Index is the highest
+// derived index and absent before the wallet derived any key
+type GetCurrentDerivationIndexResponse struct {
+	Response
+	Index mo.Option[uint32] `json:"index"`
+}
+
+// GetCurrentDerivationIndex returns the highest derivation index the wallet watches
+func (s *WalletService) GetCurrentDerivationIndex(ctx context.Context) (*GetCurrentDerivationIndexResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_current_derivation_index", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetCurrentDerivationIndexResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// ExtendDerivationIndexOptions options for extend_derivation_index, Index has to be above the current index
+// and at most MaxDerivationIndexDelta past it
+type ExtendDerivationIndexOptions struct {
+	Index uint32 `json:"index"`
+}
+
+// ExtendDerivationIndexResponse response from extend_derivation_index
+type ExtendDerivationIndexResponse struct {
+	Response
+	Index mo.Option[uint32] `json:"index"`
+}
+
+// ExtendDerivationIndex derives and watches the puzzle hashes of every wallet up to Index
+func (s *WalletService) ExtendDerivationIndex(ctx context.Context, opts *ExtendDerivationIndexOptions) (*ExtendDerivationIndexResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "extend_derivation_index", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &ExtendDerivationIndexResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// currentDerivationIndex returns the highest derived index, 0 before the wallet derived any key
+func (cli *Client) currentDerivationIndex(ctx context.Context) (uint32, error) {
+	resp, httpResp, err := cli.walletService.GetCurrentDerivationIndex(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return 0, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return 0, rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return 0, rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	return resp.Index.OrEmpty(), nil
+}
+
+// extendDerivationIndex derives up to index in steps the wallet accepts. The wallet rejects an index at or
+// below its current one, so an index another caller already reached counts as extended
+func (cli *Client) extendDerivationIndex(ctx context.Context, current, index uint32) error {
+	for current < index {
+		step := index
+		if step-current > MaxDerivationIndexDelta {
+			step = current + MaxDerivationIndexDelta
+		}
+
+		err := cli.extendDerivationIndexTo(ctx, step)
+		if err != nil {
+			reached, indexErr := cli.currentDerivationIndex(ctx)
+			if indexErr != nil || reached < step {
+				return err
+			}
+			step = reached
+		}
+		current = step
+	}
+	return nil
+}
+
+func (cli *Client) extendDerivationIndexTo(ctx context.Context, index uint32) error {
+	resp, httpResp, err := cli.walletService.ExtendDerivationIndex(ctx, &ExtendDerivationIndexOptions{
+		Index: index,
+	})
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	return nil
+}
+
+// verifyWalletAddress checks that address is the standard puzzle of a key in the derivation table of the
+// wallet. The wallet only signs for puzzle hashes it derived, and every derived puzzle hash is watched, so
+// the synthetic public key it signs with must hash to the puzzle hash of the address
+func (cli *Client) verifyWalletAddress(ctx context.Context, address string, puzzleHash types.Bytes32) error {
+	resp, httpResp, err := cli.walletService.SignMessageByAddress(ctx, &SignMessageByAddressOptions{
+		Address: address,
+		Message: address,
+	})
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return fmt.Errorf("address %v is not derived by the wallet,err: %v", address, *resp.Error.ToPointer())
+	}
+
+	pubKey := resp.PubKey.OrEmpty()
+	derived, err := puzzlehash.NewPuzzleHashBytesFromPkBytes(pubKey[:])
+	if err != nil {
+		return fmt.Errorf("invalid wallet public key for %v,err: %v", address, err)
+	}
+	if !bytes.Equal(derived, puzzleHash[:]) {
+		return fmt.Errorf("address %v does not match the wallet key 0x%x", address, pubKey[:])
+	}
+	return nil
+}
+
+// AllocateDepositAddresses returns n unused addresses of the standard wallet. The wallet has no RPC listing
+// the puzzle hashes of a derivation range, so it first extends the derivation index by n, which derives and
+// watches the keys, and then takes the addresses from get_next_address. Every address is checked to be the
+// puzzle hash of a key the wallet derived, and the wallet has to watch up to the extended index afterwards
+func (cli *Client) AllocateDepositAddresses(ctx context.Context, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	current, err := cli.currentDerivationIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first unused index is at most current, so n more keys cover every address handed out below
+	target := current + uint32(n)
+	err = cli.extendDerivationIndex(ctx, current, target)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, n)
+	seen := map[types.Bytes32]bool{}
+	for len(addresses) < n {
+		resp, httpResp, err := cli.walletService.GetNextAddress(ctx, &GetNextAddressOptions{
+			NewAddress: true,
+			WalletID:   standardWalletID,
+		})
+		if err != nil {
+			return addresses, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return addresses, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return addresses, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return addresses, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		address := resp.Address.OrEmpty()
+		_, puzzleHash, err := puzzlehash.GetPuzzleHashFromAddress(address)
+		if err != nil || puzzleHash == nil {
+			return addresses, fmt.Errorf("invalid address %q,err: %v", address, err)
+		}
+		if seen[*puzzleHash] {
+			return addresses, fmt.Errorf("wallet returned address %v twice in one allocation", address)
+		}
+
+		err = cli.verifyWalletAddress(ctx, address, *puzzleHash)
+		if err != nil {
+			return addresses, err
+		}
+		seen[*puzzleHash] = true
+		addresses = append(addresses, address)
+	}
+
+	watched, err := cli.currentDerivationIndex(ctx)
+	if err != nil {
+		return addresses, err
+	}
+	if watched < target {
+		return addresses, fmt.Errorf("wallet watches up to index %v, expected %v", watched, target)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,142 @@
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/account"
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+// derivationWallet stands in for a wallet whose keys are derived up to index, next is the first unused one
+type derivationWallet struct {
+	index   uint32
+	next    uint32
+	extends []uint32
+	keys    map[string][]byte // address to the public key the wallet signs with
+	foreign bool              // hand out addresses the wallet has no key for
+	race    uint32            // another caller extends to this index before the next extend lands
+}
+
+func newDerivationWalletServer(t *testing.T, wallet *derivationWallet) *httptest.Server {
+	wallet.keys = map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/get_current_derivation_index":
+			_, _ = fmt.Fprintf(w, `{"success":true,"index":%v}`, wallet.index)
+		case "/extend_derivation_index":
+			body := client.ExtendDerivationIndexOptions{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			wallet.extends = append(wallet.extends, body.Index)
+			if wallet.race > wallet.index {
+				wallet.index, wallet.race = wallet.race, 0
+			}
+			if body.Index <= wallet.index {
+				_, _ = fmt.Fprintf(w, `{"success":false,"error":"New derivation index must be greater than current index: %v"}`,
+					wallet.index)
+				return
+			}
+			if body.Index-wallet.index > client.MaxDerivationIndexDelta {
+				_, _ = w.Write([]byte(`{"success":false,"error":"Too many derivations requested"}`))
+				return
+			}
+			wallet.index = body.Index
+			_, _ = fmt.Fprintf(w, `{"success":true,"index":%v}`, wallet.index)
+		case "/get_next_address":
+			if wallet.next > wallet.index {
+				wallet.index = wallet.next
+			}
+			wallet.next++
+
+			key, err := account.GenAccount()
+			if !assert.Nil(t, err) {
+				return
+			}
+			address, _ := key.GetAddress(false)
+			pubKey, _ := key.GetPKBytes()
+			if !wallet.foreign {
+				wallet.keys[address] = pubKey
+			}
+			_, _ = fmt.Fprintf(w, `{"success":true,"wallet_id":1,"address":"%v"}`, address)
+		case "/sign_message_by_address":
+			body := map[string]interface{}{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			pubKey, ok := wallet.keys[body["address"].(string)]
+			if !ok {
+				_, _ = w.Write([]byte(`{"success":false,"error":"No private key for puzzle hash"}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"success":true,"pubkey":"0x%v","signature":"0x%v"}`,
+				hex.EncodeToString(pubKey), hex.EncodeToString(make([]byte, 96)))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAllocateDepositAddresses(t *testing.T) {
+	wallet := &derivationWallet{index: 100, next: 40}
+	server := newDerivationWalletServer(t, wallet)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	addresses, err := cli.AllocateDepositAddresses(context.Background(), 3)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(addresses))
+	assert.Equal(t, []uint32{103}, wallet.extends)
+
+	index, _, err := cli.Wallet().GetCurrentDerivationIndex(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(103), index.Index.MustGet())
+
+	addresses, err = cli.AllocateDepositAddresses(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Nil(t, addresses)
+}
+
+func TestAllocateDepositAddressesExtendsInSteps(t *testing.T) {
+	wallet := &derivationWallet{index: 10, next: 10}
+	server := newDerivationWalletServer(t, wallet)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	addresses, err := cli.AllocateDepositAddresses(context.Background(), 2500)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2500, len(addresses))
+	assert.Equal(t, []uint32{1010, 2010, 2510}, wallet.extends)
+}
+
+func TestAllocateDepositAddressesRacingExtend(t *testing.T) {
+	// another caller extends past the target in between, the rejected extend counts as done
+	wallet := &derivationWallet{index: 100, next: 100, race: 200}
+	server := newDerivationWalletServer(t, wallet)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	addresses, err := cli.AllocateDepositAddresses(context.Background(), 5)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 5, len(addresses))
+	assert.Equal(t, []uint32{105}, wallet.extends)
+}
+
+func TestAllocateDepositAddressesForeignAddress(t *testing.T) {
+	wallet := &derivationWallet{index: 100, next: 40, foreign: true}
+	server := newDerivationWalletServer(t, wallet)
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	addresses, err := cli.AllocateDepositAddresses(context.Background(), 3)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(addresses))
+}
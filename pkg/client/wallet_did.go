@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// DIDCreateOptions options for creating a DID through create_new_wallet
+type DIDCreateOptions struct {
+	Amount               uint64            `json:"amount"` // mojos of the singleton, must be odd
+	Fee                  uint64            `json:"fee"`    // not required
+	BackupDIDs           []string          `json:"backup_dids"`
+	NumOfBackupIDsNeeded uint64            `json:"num_of_backup_ids_needed"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+}
+
+// didCreateRequest is the create_new_wallet body of DIDCreate
+type didCreateRequest struct {
+	WalletType string `json:"wallet_type"`
+	DIDType    string `json:"did_type"`
+	DIDCreateOptions
+}
+
+// DIDCreateResponse response from create_new_wallet for a DID wallet
+type DIDCreateResponse struct {
+	Response
+	Type     mo.Option[types.WalletType] `json:"type"`
+	MyDID    mo.Option[string]           `json:"my_did"`
+	WalletID mo.Option[uint32]           `json:"wallet_id"`
+}
+
+// DIDCreate creates a DID wallet with a new DID singleton
+func (s *WalletService) DIDCreate(ctx context.Context, opts *DIDCreateOptions) (*DIDCreateResponse, *http.Response, error) {
+	create := &didCreateRequest{
+		WalletType: "did_wallet",
+		DIDType:    "new",
+	}
+	if opts != nil {
+		create.DIDCreateOptions = *opts
+	}
+	if create.BackupDIDs == nil {
+		create.BackupDIDs = []string{}
+	}
+
+	request, err := s.NewRequest(ctx, "create_new_wallet", create)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDCreateResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDWalletOptions options of the DID calls taking only a wallet id
+type DIDWalletOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetDIDResponse response from did_get_did
+type DIDGetDIDResponse struct {
+	Response
+	WalletID mo.Option[uint32]        `json:"wallet_id"`
+	MyDID    mo.Option[string]        `json:"my_did"`
+	CoinID   mo.Option[types.Bytes32] `json:"coin_id"` // the current DID coin
+}
+
+// DIDGetDID returns the DID of a DID wallet
+func (s *WalletService) DIDGetDID(ctx context.Context, opts *DIDWalletOptions) (*DIDGetDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_get_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetInfoOptions options for did_get_info
+type DIDGetInfoOptions struct {
+	CoinID string `json:"coin_id"` // the DID, did:chia:..., or a DID coin id
+	Latest bool   `json:"latest"`  // follow the singleton to its latest coin
+}
+
+// DIDGetInfoResponse response from did_get_info
+type DIDGetInfoResponse struct {
+	Response
+	DIDID            mo.Option[string]            `json:"did_id"`
+	LatestCoin       mo.Option[types.Bytes32]     `json:"latest_coin"`
+	P2Address        mo.Option[string]            `json:"p2_address"`
+	PublicKey        mo.Option[HexBytes]          `json:"public_key"`
+	RecoveryListHash mo.Option[types.Bytes32]     `json:"recovery_list_hash"`
+	NumVerification  mo.Option[uint64]            `json:"num_verification"`
+	Metadata         mo.Option[map[string]string] `json:"metadata"`
+	LauncherID       mo.Option[types.Bytes32]     `json:"launcher_id"`
+	FullPuzzle       mo.Option[HexBytes]          `json:"full_puzzle"`
+	Solution         mo.Option[interface{}]       `json:"solution"`
+	Hints            mo.Option[[]HexBytes]        `json:"hints"`
+}
+
+// DIDGetInfo returns the on chain state of any DID
+func (s *WalletService) DIDGetInfo(ctx context.Context, opts *DIDGetInfoOptions) (*DIDGetInfoResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_get_info", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetInfoResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDUpdateMetadataOptions options for did_update_metadata
+type DIDUpdateMetadataOptions struct {
+	WalletID uint32            `json:"wallet_id"`
+	Metadata map[string]string `json:"metadata"` // replaces the whole metadata
+	Fee      uint64            `json:"fee"`      // not required
+}
+
+// DIDUpdateMetadataResponse response from did_update_metadata
+type DIDUpdateMetadataResponse struct {
+	Response
+	WalletID     mo.Option[uint32]                    `json:"wallet_id"`
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// DIDUpdateMetadata spends the DID to change its metadata
+func (s *WalletService) DIDUpdateMetadata(ctx context.Context, opts *DIDUpdateMetadataOptions) (*DIDUpdateMetadataResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_update_metadata", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDUpdateMetadataResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDTransferDIDOptions options for did_transfer_did
+type DIDTransferDIDOptions struct {
+	WalletID         uint32 `json:"wallet_id"`
+	InnerAddress     string `json:"inner_address"`
+	Fee              uint64 `json:"fee"`                // not required
+	WithRecoveryInfo bool   `json:"with_recovery_info"` // keep the recovery list, the new owner needs a recent wallet
+}
+
+// DIDTransferDIDResponse response from did_transfer_did
+type DIDTransferDIDResponse struct {
+	Response
+	TransactionID mo.Option[string]                  `json:"transaction_id"`
+	Transaction   mo.Option[types.TransactionRecord] `json:"transaction"`
+}
+
+// DIDTransferDID sends the DID to another address
+func (s *WalletService) DIDTransferDID(ctx context.Context, opts *DIDTransferDIDOptions) (*DIDTransferDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_transfer_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDTransferDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetRecoveryListResponse response from did_get_recovery_list
+type DIDGetRecoveryListResponse struct {
+	Response
+	WalletID     mo.Option[uint32]   `json:"wallet_id"`
+	RecoveryList mo.Option[[]string] `json:"recovery_list"`
+	NumRequired  mo.Option[uint64]   `json:"num_required"`
+}
+
+// DIDGetRecoveryList returns the DIDs that can recover the DID of a wallet
+func (s *WalletService) DIDGetRecoveryList(ctx context.Context, opts *DIDWalletOptions) (*DIDGetRecoveryListResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_get_recovery_list", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetRecoveryListResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDUpdateRecoveryIDsOptions options for did_update_recovery_ids
+type DIDUpdateRecoveryIDsOptions struct {
+	WalletID                 uint32   `json:"wallet_id"`
+	NewList                  []string `json:"new_list"`
+	NumVerificationsRequired uint64   `json:"num_verifications_required"`
+	Fee                      uint64   `json:"fee"` // not required
+}
+
+// DIDUpdateRecoveryIDsResponse response from did_update_recovery_ids
+type DIDUpdateRecoveryIDsResponse struct {
+	Response
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// DIDUpdateRecoveryIDs spends the DID to change its recovery list
+func (s *WalletService) DIDUpdateRecoveryIDs(ctx context.Context, opts *DIDUpdateRecoveryIDsOptions) (*DIDUpdateRecoveryIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_update_recovery_ids", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDUpdateRecoveryIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDMessageSpendOptions options for did_message_spend
+type DIDMessageSpendOptions struct {
+	WalletID            uint32     `json:"wallet_id"`
+	CoinAnnouncements   []HexBytes `json:"coin_announcements"`
+	PuzzleAnnouncements []HexBytes `json:"puzzle_announcements"`
+}
+
+// DIDMessageSpendResponse response from did_message_spend
+type DIDMessageSpendResponse struct {
+	Response
+	SpendBundle mo.Option[types.SpendBundle] `json:"spend_bundle"`
+}
+
+// DIDMessageSpend returns an unpushed spend of the DID that creates the given announcements
+func (s *WalletService) DIDMessageSpend(ctx context.Context, opts *DIDMessageSpendOptions) (*DIDMessageSpendResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_message_spend", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDMessageSpendResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDFindLostDIDOptions options for did_find_lost_did
+type DIDFindLostDIDOptions struct {
+	CoinID           string            `json:"coin_id"` // the DID, did:chia:..., or a DID coin id
+	RecoveryListHash *types.Bytes32    `json:"recovery_list_hash,omitempty"`
+	NumVerification  *uint64           `json:"num_verification,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// DIDFindLostDIDResponse response from did_find_lost_did
+type DIDFindLostDIDResponse struct {
+	Response
+	LatestCoinID mo.Option[types.Bytes32] `json:"latest_coin_id"`
+}
+
+// DIDFindLostDID recovers a DID the wallet owns but does not track, e.g. after a resync
+func (s *WalletService) DIDFindLostDID(ctx context.Context, opts *DIDFindLostDIDOptions) (*DIDFindLostDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "did_find_lost_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDFindLostDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestDIDCreate(t *testing.T) {
+	sent := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_, _ = w.Write([]byte(`{"success":true,"type":8,"my_did":"did:chia:1qqqq","wallet_id":4}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	resp, _, err := service.DIDCreate(context.Background(), &client.DIDCreateOptions{Amount: 1})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "did:chia:1qqqq", resp.MyDID.OrEmpty())
+	assert.Equal(t, uint32(4), resp.WalletID.OrEmpty())
+
+	assert.Equal(t, "did_wallet", sent["wallet_type"])
+	assert.Equal(t, "new", sent["did_type"])
+	assert.Equal(t, []interface{}{}, sent["backup_dids"])
+
+	_, _, err = service.DIDCreate(context.Background(), nil)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "did_wallet", sent["wallet_type"])
+	assert.Equal(t, float64(0), sent["amount"])
+}
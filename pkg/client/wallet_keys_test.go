@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestWaitWalletSynced(t *testing.T) {
+	paths := []string{}
+	statusCalls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/log_in":
+			_, _ = w.Write([]byte(`{"success":true,"fingerprint":1234}`))
+		case "/get_sync_status":
+			synced := atomic.AddInt32(&statusCalls, 1) >= 3
+			if synced {
+				_, _ = w.Write([]byte(`{"success":true,"synced":true,"syncing":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success":true,"synced":false,"syncing":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient(
+		"127.0.0.1:1",
+		client.WithWalletEndpoint(endpointOf(server)),
+		client.WithBasePath(""),
+		client.WithWalletSyncInterval(10*time.Millisecond),
+	)
+
+	err := cli.WaitWalletSynced(context.Background(), 1234)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "/log_in", paths[0])
+	assert.Equal(t, int32(3), atomic.LoadInt32(&statusCalls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	atomic.StoreInt32(&statusCalls, -1000)
+	err = cli.WaitWalletSynced(ctx, 1234)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestWaitWalletSyncedLoginRejected(t *testing.T) {
+	// a login rejected without an error message must not count as logged in
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"success":false}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	err := cli.WaitWalletSynced(context.Background(), 1234)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"/log_in"}, paths)
+}
@@ -0,0 +1,439 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// DefaultNFTChunkSize is how many NFTs the bulk helpers put in one call unless WithNFTChunkSize says otherwise.
+// The node refuses a spend bundle costing more than half of MAX_BLOCK_COST_CLVM, and 25 NFT spends with a fee
+// and a DID spend stay well below that. Measure the cost of your spends before raising it
+const DefaultNFTChunkSize = 25
+
+// NFTMintMetadata describes one NFT of nft_mint_bulk
+type NFTMintMetadata struct {
+	Hash          string   `json:"hash"`
+	URIs          []string `json:"uris"`
+	MetaHash      string   `json:"meta_hash,omitempty"`
+	MetaURIs      []string `json:"meta_uris,omitempty"`
+	LicenseHash   string   `json:"license_hash,omitempty"`
+	LicenseURIs   []string `json:"license_uris,omitempty"`
+	EditionNumber uint32   `json:"edition_number,omitempty"`
+	EditionTotal  uint32   `json:"edition_total,omitempty"`
+}
+
+// NFTMintBulkOptions options for nft_mint_bulk. TargetList is empty or has one address per metadata,
+// MintNumberStart and MintTotal number the NFTs of a collection minted over several calls
+type NFTMintBulkOptions struct {
+	WalletID          uint32            `json:"wallet_id"`
+	MetadataList      []NFTMintMetadata `json:"metadata_list"`
+	RoyaltyAddress    string            `json:"royalty_address,omitempty"`
+	RoyaltyPercentage uint32            `json:"royalty_percentage,omitempty"` // in basis points
+	TargetList        []string          `json:"target_list,omitempty"`
+	MintNumberStart   uint32            `json:"mint_number_start,omitempty"`
+	MintTotal         uint32            `json:"mint_total,omitempty"`
+	MintFromDID       bool              `json:"mint_from_did"`
+	Fee               uint64            `json:"fee"`  // not required
+	Push              bool              `json:"push"` // the node only returns the spend bundle unless set
+}
+
+// NFTMintBulkResponse response from nft_mint_bulk
+type NFTMintBulkResponse struct {
+	Response
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	NFTIDList    mo.Option[[]string]                  `json:"nft_id_list"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// NFTMintBulk mints the NFTs of MetadataList in one spend
+func (s *WalletService) NFTMintBulk(ctx context.Context, opts *NFTMintBulkOptions) (*NFTMintBulkResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_mint_bulk", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTMintBulkResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTCoin identifies an NFT coin and the NFT wallet holding it
+type NFTCoin struct {
+	NFTCoinID string `json:"nft_coin_id"`
+	WalletID  uint32 `json:"wallet_id"`
+}
+
+// NFTTransferBulkOptions options for nft_transfer_bulk
+type NFTTransferBulkOptions struct {
+	NFTCoinList   []NFTCoin `json:"nft_coin_list"`
+	TargetAddress string    `json:"target_address"`
+	Fee           uint64    `json:"fee"` // not required
+}
+
+// NFTBulkResponse response from nft_transfer_bulk and nft_set_did_bulk, WalletID lists the wallets spent from
+type NFTBulkResponse struct {
+	Response
+	WalletID     mo.Option[[]uint32]                  `json:"wallet_id"`
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	TxNum        mo.Option[uint32]                    `json:"tx_num"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// NFTTransferBulk sends the NFTs of NFTCoinList to one address in one spend
+func (s *WalletService) NFTTransferBulk(ctx context.Context, opts *NFTTransferBulkOptions) (*NFTBulkResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_transfer_bulk", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTBulkResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTSetDIDBulkOptions options for nft_set_did_bulk, an empty DIDID removes the owner
+type NFTSetDIDBulkOptions struct {
+	NFTCoinList []NFTCoin `json:"nft_coin_list"`
+	DIDID       string    `json:"did_id"`
+	Fee         uint64    `json:"fee"` // not required
+}
+
+// NFTSetDIDBulk assigns the NFTs of NFTCoinList to a DID in one spend
+func (s *WalletService) NFTSetDIDBulk(ctx context.Context, opts *NFTSetDIDBulkOptions) (*NFTBulkResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_set_did_bulk", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTBulkResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTSetNFTDIDOptions options for nft_set_nft_did
+type NFTSetNFTDIDOptions struct {
+	WalletID  uint32 `json:"wallet_id"`
+	DIDID     string `json:"did_id"`
+	NFTCoinID string `json:"nft_coin_id"`
+	Fee       uint64 `json:"fee"` // not required
+}
+
+// NFTSetNFTDIDResponse response from nft_set_nft_did
+type NFTSetNFTDIDResponse struct {
+	Response
+	WalletID     mo.Option[uint32]                    `json:"wallet_id"`
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// NFTSetNFTDID assigns one NFT to a DID
+func (s *WalletService) NFTSetNFTDID(ctx context.Context, opts *NFTSetNFTDIDOptions) (*NFTSetNFTDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_set_nft_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTSetNFTDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTSetNFTStatusOptions options for nft_set_nft_status
+type NFTSetNFTStatusOptions struct {
+	WalletID      uint32 `json:"wallet_id"`
+	CoinID        string `json:"coin_id"`
+	InTransaction bool   `json:"in_transaction"`
+}
+
+// NFTSetNFTStatusResponse response from nft_set_nft_status
+type NFTSetNFTStatusResponse struct {
+	Response
+}
+
+// NFTSetNFTStatus marks an NFT as pending in a transaction or clears the mark, e.g. after a failed transfer
+func (s *WalletService) NFTSetNFTStatus(ctx context.Context, opts *NFTSetNFTStatusOptions) (*NFTSetNFTStatusResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_set_nft_status", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTSetNFTStatusResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTCountNFTsOptions options for nft_count_nfts, every NFT wallet is counted when WalletID is nil
+type NFTCountNFTsOptions struct {
+	WalletID *uint32 `json:"wallet_id,omitempty"`
+}
+
+// NFTCountNFTsResponse response from nft_count_nfts
+type NFTCountNFTsResponse struct {
+	Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+	Count    mo.Option[uint64] `json:"count"`
+}
+
+// NFTCountNFTs returns the number of NFTs the wallet holds
+func (s *WalletService) NFTCountNFTs(ctx context.Context, opts *NFTCountNFTsOptions) (*NFTCountNFTsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_count_nfts", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTCountNFTsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTWalletWithDID is an NFT wallet bound to a DID
+type NFTWalletWithDID struct {
+	WalletID    uint32 `json:"wallet_id"`
+	DIDID       string `json:"did_id"`
+	DIDWalletID uint32 `json:"did_wallet_id"`
+}
+
+// NFTGetWalletsWithDIDsResponse response from nft_get_wallets_with_dids
+type NFTGetWalletsWithDIDsResponse struct {
+	Response
+	NFTWallets mo.Option[[]NFTWalletWithDID] `json:"nft_wallets"`
+}
+
+// NFTGetWalletsWithDIDs returns the NFT wallets bound to a DID
+func (s *WalletService) NFTGetWalletsWithDIDs(ctx context.Context) (*NFTGetWalletsWithDIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_get_wallets_with_dids", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTGetWalletsWithDIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RoyaltyAsset is an NFT asset paying royalties when traded
+type RoyaltyAsset struct {
+	Asset             string `json:"asset"`
+	RoyaltyAddress    string `json:"royalty_address"`
+	RoyaltyPercentage uint32 `json:"royalty_percentage"` // in basis points
+}
+
+// FungibleAsset is an asset paid for royalty assets, e.g. xch or a CAT
+type FungibleAsset struct {
+	Asset  string `json:"asset"`
+	Amount uint64 `json:"amount"`
+}
+
+// NFTCalculateRoyaltiesOptions options for nft_calculate_royalties
+type NFTCalculateRoyaltiesOptions struct {
+	RoyaltyAssets  []RoyaltyAsset  `json:"royalty_assets"`
+	FungibleAssets []FungibleAsset `json:"fungible_assets"`
+}
+
+// NFTRoyalty is the royalty owed to Address in the fungible Asset
+type NFTRoyalty struct {
+	Asset   string `json:"asset"`
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+// NFTCalculateRoyaltiesResponse response from nft_calculate_royalties, Royalties is keyed by royalty asset
+type NFTCalculateRoyaltiesResponse struct {
+	Response
+	Royalties map[string][]NFTRoyalty `json:"-"`
+}
+
+// UnmarshalJSON collects the royalties, which the node returns as top level fields named after the royalty assets
+func (r *NFTCalculateRoyaltiesResponse) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &r.Response); err != nil {
+		return err
+	}
+
+	r.Royalties = map[string][]NFTRoyalty{}
+	for key, value := range fields {
+		if key == "success" || key == "error" {
+			continue
+		}
+		royalties := []NFTRoyalty{}
+		if err := json.Unmarshal(value, &royalties); err != nil {
+			return fmt.Errorf("invalid royalties of %v: %v", key, err)
+		}
+		r.Royalties[key] = royalties
+	}
+	return nil
+}
+
+// NFTCalculateRoyalties returns the royalties owed when trading royalty assets for fungible assets
+func (s *WalletService) NFTCalculateRoyalties(ctx context.Context, opts *NFTCalculateRoyaltiesOptions) (*NFTCalculateRoyaltiesResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "nft_calculate_royalties", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTCalculateRoyaltiesResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// chunks splits items into slices of at most size items
+func chunks[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		size = len(items)
+	}
+	chunked := [][]T{}
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunked = append(chunked, items[start:end])
+	}
+	return chunked
+}
+
+// MintNFTsBulk mints a collection with nft_mint_bulk in calls of at most the client NFT chunk size. Every call
+// is pushed, so the next one spends other coins, and Fee is paid per call. With MintFromDID every call spends the
+// DID coin, which the next call cannot spend before it is confirmed, so such a mint has to fit in one call.
+// It returns the responses of the calls done, also when a later call failed
+func (cli *Client) MintNFTsBulk(ctx context.Context, opts *NFTMintBulkOptions) ([]*NFTMintBulkResponse, error) {
+	if len(opts.TargetList) > 0 && len(opts.TargetList) != len(opts.MetadataList) {
+		return nil, fmt.Errorf("%v targets for %v NFTs", len(opts.TargetList), len(opts.MetadataList))
+	}
+	if opts.MintFromDID && len(opts.MetadataList) > cli.nftChunkSize {
+		return nil, fmt.Errorf("minting %v NFTs from a DID needs more than one call of %v, split the collection "+
+			"and mint the next part once the previous one is confirmed", len(opts.MetadataList), cli.nftChunkSize)
+	}
+
+	start := opts.MintNumberStart
+	if start == 0 {
+		start = 1
+	}
+	total := opts.MintTotal
+	if total == 0 {
+		total = start - 1 + uint32(len(opts.MetadataList))
+	}
+
+	responses := []*NFTMintBulkResponse{}
+	offset := 0
+	for _, metadata := range chunks(opts.MetadataList, cli.nftChunkSize) {
+		mint := *opts
+		mint.MetadataList = metadata
+		mint.MintNumberStart = start + uint32(offset)
+		mint.MintTotal = total
+		mint.Push = true
+		if len(opts.TargetList) > 0 {
+			mint.TargetList = opts.TargetList[offset : offset+len(metadata)]
+		}
+		offset += len(metadata)
+
+		resp, httpResp, err := cli.walletService.NFTMintBulk(ctx, &mint)
+		if err != nil {
+			return responses, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return responses, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return responses, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return responses, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+// bulkNFTs runs call for every chunk of size coins and returns the responses of the calls done
+func bulkNFTs(coins []NFTCoin, size int, call func([]NFTCoin) (*NFTBulkResponse, *http.Response, error)) ([]*NFTBulkResponse, error) {
+	responses := []*NFTBulkResponse{}
+	for _, chunk := range chunks(coins, size) {
+		resp, httpResp, err := call(chunk)
+		if err != nil {
+			return responses, err
+		}
+
+		if httpResp.StatusCode != 200 {
+			return responses, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+		}
+
+		if resp == nil {
+			return responses, rpcerr.ErrNoResponse
+		}
+
+		if resp.Error.ToPointer() != nil {
+			return responses, rpcerr.New(*resp.Error.ToPointer())
+		}
+
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// TransferNFTsBulk sends NFTs to one address with nft_transfer_bulk in calls of at most the client NFT chunk
+// size, Fee is paid per call. It returns the responses of the calls done, also when a later call failed
+func (cli *Client) TransferNFTsBulk(ctx context.Context, opts *NFTTransferBulkOptions) ([]*NFTBulkResponse, error) {
+	return bulkNFTs(opts.NFTCoinList, cli.nftChunkSize, func(coins []NFTCoin) (*NFTBulkResponse, *http.Response, error) {
+		transfer := *opts
+		transfer.NFTCoinList = coins
+		return cli.walletService.NFTTransferBulk(ctx, &transfer)
+	})
+}
+
+// SetNFTsDIDBulk assigns NFTs to a DID with nft_set_did_bulk in calls of at most the client NFT chunk size,
+// Fee is paid per call. It returns the responses of the calls done, also when a later call failed
+func (cli *Client) SetNFTsDIDBulk(ctx context.Context, opts *NFTSetDIDBulkOptions) ([]*NFTBulkResponse, error) {
+	return bulkNFTs(opts.NFTCoinList, cli.nftChunkSize, func(coins []NFTCoin) (*NFTBulkResponse, *http.Response, error) {
+		set := *opts
+		set.NFTCoinList = coins
+		return cli.walletService.NFTSetDIDBulk(ctx, &set)
+	})
+}
@@ -0,0 +1,139 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestMintNFTsBulk(t *testing.T) {
+	bodies := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		if len(bodies) == 3 {
+			_, _ = w.Write([]byte(`{"success":false,"error":"insufficient funds"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"nft_id_list":["nft1"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	metadata := []client.NFTMintMetadata{}
+	targets := []string{}
+	for i := 0; i < 60; i++ {
+		metadata = append(metadata, client.NFTMintMetadata{Hash: fmt.Sprintf("%064x", i), URIs: []string{"https://nft.example"}})
+		targets = append(targets, fmt.Sprintf("txch%v", i))
+	}
+
+	responses, err := cli.MintNFTsBulk(context.Background(), &client.NFTMintBulkOptions{
+		WalletID:     3,
+		MetadataList: metadata,
+		TargetList:   targets,
+	})
+	assert.EqualError(t, err, "insufficient funds")
+	assert.Equal(t, 2, len(responses))
+
+	if assert.Equal(t, 3, len(bodies)) {
+		second := bodies[1]
+		assert.Equal(t, float64(26), second["mint_number_start"])
+		assert.Equal(t, float64(60), second["mint_total"])
+		assert.Equal(t, true, second["push"])
+		assert.Equal(t, 25, len(second["metadata_list"].([]interface{})))
+		assert.Equal(t, "txch25", second["target_list"].([]interface{})[0])
+
+		third := bodies[2]
+		assert.Equal(t, 10, len(third["metadata_list"].([]interface{})))
+		assert.Equal(t, 10, len(third["target_list"].([]interface{})))
+	}
+
+	_, err = cli.MintNFTsBulk(context.Background(), &client.NFTMintBulkOptions{
+		MetadataList: metadata,
+		TargetList:   targets[:1],
+	})
+	assert.NotNil(t, err)
+
+	// every call spends the DID coin, so a DID mint is refused before it is split
+	_, err = cli.MintNFTsBulk(context.Background(), &client.NFTMintBulkOptions{
+		WalletID:     3,
+		MetadataList: metadata,
+		MintFromDID:  true,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, len(bodies))
+}
+
+func TestTransferNFTsBulk(t *testing.T) {
+	sizes := []int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := client.NFTTransferBulkOptions{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sizes = append(sizes, len(body.NFTCoinList))
+		_, _ = w.Write([]byte(`{"success":true,"wallet_id":[3],"tx_num":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	coins := []client.NFTCoin{}
+	for i := 0; i < 30; i++ {
+		coins = append(coins, client.NFTCoin{NFTCoinID: fmt.Sprintf("0x%064x", i), WalletID: 3})
+	}
+
+	responses, err := cli.TransferNFTsBulk(context.Background(), &client.NFTTransferBulkOptions{
+		NFTCoinList:   coins,
+		TargetAddress: testAddress,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []int{25, 5}, sizes)
+	assert.Equal(t, 2, len(responses))
+	assert.Equal(t, []uint32{3}, responses[0].WalletID.MustGet())
+
+	sizes = []int{}
+	cli = client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""),
+		client.WithNFTChunkSize(12))
+	_, err = cli.TransferNFTsBulk(context.Background(), &client.NFTTransferBulkOptions{
+		NFTCoinList:   coins,
+		TargetAddress: testAddress,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []int{12, 12, 6}, sizes)
+}
+
+func TestNFTCalculateRoyalties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true,"nft1":[{"asset":"xch","address":"txch1royalty","amount":250}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	resp, _, err := service.NFTCalculateRoyalties(context.Background(), &client.NFTCalculateRoyaltiesOptions{
+		RoyaltyAssets:  []client.RoyaltyAsset{{Asset: "nft1", RoyaltyAddress: "txch1royalty", RoyaltyPercentage: 250}},
+		FungibleAssets: []client.FungibleAsset{{Asset: "xch", Amount: 10000}},
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.True(t, resp.Success)
+	assert.Equal(t, map[string][]client.NFTRoyalty{
+		"nft1": {{Asset: "xch", Address: "txch1royalty", Amount: 250}},
+	}, resp.Royalties)
+}
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// SendNotificationOptions options for send_notification
+type SendNotificationOptions struct {
+	Target  types.Bytes32 `json:"target"` // puzzle hash of the receiving address
+	Message HexBytes      `json:"message"`
+	Amount  uint64        `json:"amount"` // mojos sent along, receivers may ignore notifications below a minimum
+	Fee     uint64        `json:"fee"`    // not required
+}
+
+// SendNotificationResponse response from send_notification
+type SendNotificationResponse struct {
+	Response
+	Tx mo.Option[types.TransactionRecord] `json:"tx"`
+}
+
+// SendNotification sends a message to an address on chain
+func (s *WalletService) SendNotification(ctx context.Context, opts *SendNotificationOptions) (*SendNotificationResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "send_notification", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SendNotificationResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// Notification is a message received on chain by the key of the wallet
+type Notification struct {
+	ID      types.Bytes32 `json:"id"`
+	Message HexBytes      `json:"message"`
+	Amount  uint64        `json:"amount"`
+	Height  uint32        `json:"height"`
+}
+
+// Text returns the message as text, ok is false when it is not valid utf8
+func (n *Notification) Text() (text string, ok bool) {
+	return string(n.Message), utf8.Valid(n.Message)
+}
+
+// GetNotificationsOptions options for get_notifications, all notifications are returned without IDs and a range
+type GetNotificationsOptions struct {
+	IDs   []types.Bytes32 `json:"ids,omitempty"`
+	Start *uint32         `json:"start,omitempty"`
+	End   *uint32         `json:"end,omitempty"`
+}
+
+// GetNotificationsResponse response from get_notifications
+type GetNotificationsResponse struct {
+	Response
+	Notifications mo.Option[[]Notification] `json:"notifications"`
+}
+
+// GetNotifications returns the notifications the wallet received
+func (s *WalletService) GetNotifications(ctx context.Context, opts *GetNotificationsOptions) (*GetNotificationsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_notifications", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetNotificationsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DeleteNotificationsOptions options for delete_notifications, every notification is deleted without IDs
+type DeleteNotificationsOptions struct {
+	IDs []types.Bytes32 `json:"ids,omitempty"`
+}
+
+// DeleteNotificationsResponse response from delete_notifications
+type DeleteNotificationsResponse struct {
+	Response
+}
+
+// DeleteNotifications deletes received notifications from the wallet
+func (s *WalletService) DeleteNotifications(ctx context.Context, opts *DeleteNotificationsOptions) (*DeleteNotificationsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "delete_notifications", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DeleteNotificationsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DefaultNotificationPageSize is how many notifications a NotificationPoller asks for per get_notifications call
+const DefaultNotificationPageSize = 50
+
+// NotificationPoller reports each notification of a wallet once. The wallet returns its notifications newest
+// first, so a poll pages down only until it passes the height of the newest notification already reported and
+// remembers just the notifications at that height. Notifications that a syncing wallet adds below that height
+// are not reported, start polling once the wallet is synced
+type NotificationPoller struct {
+	OnError  func(error) // called by Run when a poll fails, may be nil
+	PageSize uint32      // notifications per get_notifications call, DefaultNotificationPageSize when 0
+
+	wallet *WalletService
+	mu     sync.Mutex
+	height uint32                   // height of the newest reported notification
+	seen   map[types.Bytes32]uint32 // reported notifications at height, by ID
+}
+
+// NewNotificationPoller creates a poller that reports every notification the wallet holds on its first poll
+func NewNotificationPoller(wallet *WalletService) *NotificationPoller {
+	return &NotificationPoller{
+		PageSize: DefaultNotificationPageSize,
+		wallet:   wallet,
+		seen:     map[types.Bytes32]uint32{},
+	}
+}
+
+// NotificationPoller creates a poller for the notifications of the client wallet
+func (cli *Client) NotificationPoller() *NotificationPoller {
+	return NewNotificationPoller(cli.walletService)
+}
+
+func (p *NotificationPoller) page(ctx context.Context, start, end uint32) ([]Notification, error) {
+	resp, httpResp, err := p.wallet.GetNotifications(ctx, &GetNotificationsOptions{
+		Start: &start,
+		End:   &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return nil, rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return nil, rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	return resp.Notifications.OrEmpty(), nil
+}
+
+// Poll returns the notifications received since the previous poll, the oldest first
+func (p *NotificationPoller) Poll(ctx context.Context) ([]Notification, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	size := p.PageSize
+	if size == 0 {
+		size = DefaultNotificationPageSize
+	}
+
+	notifications := []Notification{}
+	for start := uint32(0); ; start += size {
+		page, err := p.page(ctx, start, start+size)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, notification := range page {
+			if notification.Height < p.height {
+				break
+			}
+			if _, ok := p.seen[notification.ID]; !ok {
+				notifications = append(notifications, notification)
+			}
+		}
+		if uint32(len(page)) < size || page[len(page)-1].Height < p.height {
+			break
+		}
+	}
+
+	// the wallet returns the newest first
+	for i, j := 0, len(notifications)-1; i < j; i, j = i+1, j-1 {
+		notifications[i], notifications[j] = notifications[j], notifications[i]
+	}
+
+	for _, notification := range notifications {
+		if notification.Height > p.height {
+			p.height = notification.Height
+		}
+		p.seen[notification.ID] = notification.Height
+	}
+	// the next poll does not page below height, so only the notifications at height are kept
+	for id, height := range p.seen {
+		if height < p.height {
+			delete(p.seen, id)
+		}
+	}
+	return notifications, nil
+}
+
+// Run polls every interval until ctx is done and calls handle for each new notification
+func (p *NotificationPoller) Run(ctx context.Context, interval time.Duration, handle func(Notification)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		notifications, err := p.Poll(ctx)
+		if err != nil && p.OnError != nil && ctx.Err() == nil {
+			p.OnError(err)
+		}
+		for _, notification := range notifications {
+			handle(notification)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestNotificationPoller(t *testing.T) {
+	notification := func(id byte, message string, height uint32) string {
+		return fmt.Sprintf(`{"id":"0x%v","message":"%v","amount":1,"height":%v}`,
+			strings.Repeat(fmt.Sprintf("%02x", id), 32), message, height)
+	}
+	// newest first, as the wallet orders them
+	stored := []string{notification(3, "00", 12), notification(2, "ff", 11), notification(1, "726566756e64", 10)}
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts := client.GetNotificationsOptions{}
+		_ = json.NewDecoder(r.Body).Decode(&opts)
+		pages++
+		start, end := int(*opts.Start), int(*opts.End)
+		if end > len(stored) {
+			end = len(stored)
+		}
+		if start > end {
+			start = end
+		}
+		_, _ = w.Write([]byte(`{"success":true,"notifications":[` + strings.Join(stored[start:end], ",") + `]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+	poller := cli.NotificationPoller()
+	poller.PageSize = 2
+
+	notifications, err := poller.Poll(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, pages)
+	if assert.Equal(t, 3, len(notifications)) {
+		text, ok := notifications[0].Text()
+		assert.True(t, ok)
+		assert.Equal(t, "refund", text)
+		_, ok = notifications[1].Text()
+		assert.False(t, ok)
+	}
+
+	// a new block and a late notification of the newest reported height
+	stored = append([]string{notification(5, "00", 13), notification(4, "00", 12)}, stored...)
+	pages = 0
+	notifications, err = poller.Poll(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, pages)
+	if assert.Equal(t, 2, len(notifications)) {
+		assert.Equal(t, uint32(12), notifications[0].Height)
+		assert.Equal(t, uint32(13), notifications[1].Height)
+	}
+
+	// only the first page is read once it reaches below the newest reported height
+	pages = 0
+	notifications, err = poller.Poll(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, 0, len(notifications))
+}
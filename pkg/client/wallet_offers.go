@@ -0,0 +1,374 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/NpoolPlatform/chia-client/pkg/rpcerr"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// XCHAssetID keys XCH in the amounts passed to Client.CreateOffer
+const XCHAssetID = "xch"
+
+// standardWalletID is the id of the XCH wallet of every key
+const standardWalletID = 1
+
+// Trade statuses
+const (
+	TradeStatusPendingAccept  = "PENDING_ACCEPT"
+	TradeStatusPendingConfirm = "PENDING_CONFIRM"
+	TradeStatusPendingCancel  = "PENDING_CANCEL"
+	TradeStatusCancelled      = "CANCELLED"
+	TradeStatusConfirmed      = "CONFIRMED"
+	TradeStatusFailed         = "FAILED"
+)
+
+// PuzzleInfo describes the puzzle of an asset in an offer, e.g. {"type": "CAT", "tail": "0x..."}.
+// For NFTs the wallet fills it in when it holds the NFT, otherwise take it from an offer summary
+type PuzzleInfo map[string]interface{}
+
+// Type returns the outer puzzle type, CAT or singleton
+func (p PuzzleInfo) Type() string {
+	t, _ := p["type"].(string)
+	return t
+}
+
+// CATDriver returns the puzzle info of a CAT
+func CATDriver(assetID string) PuzzleInfo {
+	return PuzzleInfo{
+		"type": "CAT",
+		"tail": "0x" + strings.TrimPrefix(assetID, "0x"),
+	}
+}
+
+// DriverDict maps the asset ids of an offer to their puzzle info
+type DriverDict map[string]PuzzleInfo
+
+// OfferSummary is what an offer gives and asks for, amounts are keyed by asset id or "xch"
+type OfferSummary struct {
+	Offered   map[string]uint64 `json:"offered"`
+	Requested map[string]uint64 `json:"requested"`
+	Fees      uint64            `json:"fees"`
+	Infos     DriverDict        `json:"infos"`
+	Additions []string          `json:"additions,omitempty"` // only in advanced summaries
+	Removals  []string          `json:"removals,omitempty"`  // only in advanced summaries
+}
+
+// TradeRecord is the wallet record of an offer it made or took
+type TradeRecord struct {
+	TradeID          types.Bytes32              `json:"trade_id"`
+	Status           string                     `json:"status"`
+	ConfirmedAtIndex uint32                     `json:"confirmed_at_index"`
+	AcceptedAtTime   mo.Option[types.Timestamp] `json:"accepted_at_time"`
+	CreatedAtTime    types.Timestamp            `json:"created_at_time"`
+	IsMyOffer        bool                       `json:"is_my_offer"`
+	Sent             uint32                     `json:"sent"`
+	CoinsOfInterest  []types.Coin               `json:"coins_of_interest"`
+	Summary          OfferSummary               `json:"summary"`
+	Pending          map[string]uint64          `json:"pending"`
+}
+
+// CreateOfferForIDsOptions options for create_offer_for_ids.
+// Offer is keyed by wallet id or asset id, negative amounts are given and positive amounts are asked for
+type CreateOfferForIDsOptions struct {
+	Offer         map[string]int64 `json:"offer"`
+	DriverDict    DriverDict       `json:"driver_dict,omitempty"`
+	Fee           uint64           `json:"fee"`
+	ValidateOnly  bool             `json:"validate_only"` // do not store the offer and lock its coins
+	MinCoinAmount *uint64          `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount *uint64          `json:"max_coin_amount,omitempty"`
+}
+
+// CreateOfferForIDsResponse response from create_offer_for_ids
+type CreateOfferForIDsResponse struct {
+	Response
+	Offer       mo.Option[string]      `json:"offer"` // the offer file, offer1...
+	TradeRecord mo.Option[TradeRecord] `json:"trade_record"`
+}
+
+// CreateOfferForIDs creates an offer file
+func (s *WalletService) CreateOfferForIDs(ctx context.Context, opts *CreateOfferForIDsOptions) (*CreateOfferForIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "create_offer_for_ids", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateOfferForIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// OfferOptions options of the calls taking an offer file
+type OfferOptions struct {
+	Offer    string `json:"offer"`
+	Advanced bool   `json:"advanced,omitempty"` // get_offer_summary only, include additions and removals
+}
+
+// GetOfferSummaryResponse response from get_offer_summary
+type GetOfferSummaryResponse struct {
+	Response
+	Summary mo.Option[OfferSummary]  `json:"summary"`
+	ID      mo.Option[types.Bytes32] `json:"id"`
+}
+
+// GetOfferSummary decodes what an offer file gives and asks for
+func (s *WalletService) GetOfferSummary(ctx context.Context, opts *OfferOptions) (*GetOfferSummaryResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_offer_summary", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetOfferSummaryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CheckOfferValidityResponse response from check_offer_validity
+type CheckOfferValidityResponse struct {
+	Response
+	Valid mo.Option[bool]          `json:"valid"`
+	ID    mo.Option[types.Bytes32] `json:"id"`
+}
+
+// CheckOfferValidity tells whether the coins of an offer are still unspent
+func (s *WalletService) CheckOfferValidity(ctx context.Context, opts *OfferOptions) (*CheckOfferValidityResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "check_offer_validity", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CheckOfferValidityResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// TakeOfferOptions options for take_offer
+type TakeOfferOptions struct {
+	Offer         string  `json:"offer"`
+	Fee           uint64  `json:"fee"`
+	MinCoinAmount *uint64 `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount *uint64 `json:"max_coin_amount,omitempty"`
+}
+
+// TakeOfferResponse response from take_offer
+type TakeOfferResponse struct {
+	Response
+	TradeRecord mo.Option[TradeRecord] `json:"trade_record"`
+}
+
+// TakeOffer accepts an offer file and pushes the completed spend
+func (s *WalletService) TakeOffer(ctx context.Context, opts *TakeOfferOptions) (*TakeOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "take_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &TakeOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetOfferOptions options for get_offer
+type GetOfferOptions struct {
+	TradeID      types.Bytes32 `json:"trade_id"`
+	FileContents bool          `json:"file_contents"` // also return the offer file
+}
+
+// GetOfferResponse response from get_offer
+type GetOfferResponse struct {
+	Response
+	TradeRecord mo.Option[TradeRecord] `json:"trade_record"`
+	Offer       mo.Option[string]      `json:"offer"`
+}
+
+// GetOffer returns the trade record of an offer
+func (s *WalletService) GetOffer(ctx context.Context, opts *GetOfferOptions) (*GetOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetAllOffersOptions options for get_all_offers
+type GetAllOffersOptions struct {
+	Start              int    `json:"start"`
+	End                int    `json:"end"`
+	ExcludeMyOffers    bool   `json:"exclude_my_offers"`
+	ExcludeTakenOffers bool   `json:"exclude_taken_offers"`
+	IncludeCompleted   bool   `json:"include_completed"`
+	SortKey            string `json:"sort_key,omitempty"`
+	Reverse            bool   `json:"reverse"`
+	FileContents       bool   `json:"file_contents"`
+}
+
+// GetAllOffersResponse response from get_all_offers, Offers lines up with TradeRecords when asked for
+type GetAllOffersResponse struct {
+	Response
+	TradeRecords mo.Option[[]TradeRecord] `json:"trade_records"`
+	Offers       mo.Option[[]string]      `json:"offers"`
+}
+
+// GetAllOffers returns a page of the trade records of the wallet
+func (s *WalletService) GetAllOffers(ctx context.Context, opts *GetAllOffersOptions) (*GetAllOffersResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "get_all_offers", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetAllOffersResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CancelOfferOptions options for cancel_offer
+type CancelOfferOptions struct {
+	TradeID types.Bytes32 `json:"trade_id"`
+	Secure  bool          `json:"secure"` // spend the offered coins on chain, otherwise only forget the offer
+	Fee     uint64        `json:"fee"`
+}
+
+// CancelOfferResponse response from cancel_offer
+type CancelOfferResponse struct {
+	Response
+}
+
+// CancelOffer cancels an offer the wallet made
+func (s *WalletService) CancelOffer(ctx context.Context, opts *CancelOfferOptions) (*CancelOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cancel_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CancelOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CancelOffersOptions options for cancel_offers, without CancelAll only the offers of AssetID are cancelled
+type CancelOffersOptions struct {
+	Secure    bool   `json:"secure"`
+	BatchFee  uint64 `json:"batch_fee"`
+	BatchSize int    `json:"batch_size,omitempty"`
+	CancelAll bool   `json:"cancel_all"`
+	AssetID   string `json:"asset_id,omitempty"` // defaults to xch
+}
+
+// CancelOffersResponse response from cancel_offers
+type CancelOffersResponse struct {
+	Response
+}
+
+// CancelOffers cancels the pending offers of the wallet in batches
+func (s *WalletService) CancelOffers(ctx context.Context, opts *CancelOffersOptions) (*CancelOffersResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "cancel_offers", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CancelOffersResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CreateOffer creates an offer giving offered and asking for requested, both in mojos keyed by CAT asset id,
+// with XCHAssetID for XCH. It returns the offer file and the trade record of the wallet
+func (cli *Client) CreateOffer(ctx context.Context, offered, requested map[string]uint64, fee uint64) (string, *TradeRecord, error) {
+	offer := map[string]int64{}
+	drivers := DriverDict{}
+
+	add := func(assetID string, amount uint64, sign int64) error {
+		if amount == 0 || amount > 1<<63-1 {
+			return fmt.Errorf("invalid amount %v of %v", amount, assetID)
+		}
+		key := strings.TrimPrefix(strings.ToLower(assetID), "0x")
+		if key == XCHAssetID {
+			key = fmt.Sprint(standardWalletID)
+		} else {
+			drivers[key] = CATDriver(key)
+		}
+		if _, ok := offer[key]; ok {
+			return fmt.Errorf("asset %v both offered and requested", assetID)
+		}
+		offer[key] = sign * int64(amount)
+		return nil
+	}
+
+	for assetID, amount := range offered {
+		if err := add(assetID, amount, -1); err != nil {
+			return "", nil, err
+		}
+	}
+	for assetID, amount := range requested {
+		if err := add(assetID, amount, 1); err != nil {
+			return "", nil, err
+		}
+	}
+
+	resp, httpResp, err := cli.walletService.CreateOfferForIDs(ctx, &CreateOfferForIDsOptions{
+		Offer:      offer,
+		DriverDict: drivers,
+		Fee:        fee,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if httpResp.StatusCode != 200 {
+		return "", nil, &rpcerr.ErrHTTPStatus{Code: httpResp.StatusCode}
+	}
+
+	if resp == nil {
+		return "", nil, rpcerr.ErrNoResponse
+	}
+
+	if resp.Error.ToPointer() != nil {
+		return "", nil, rpcerr.New(*resp.Error.ToPointer())
+	}
+
+	if resp.Offer.ToPointer() == nil {
+		return "", nil, rpcerr.ErrNoResponse
+	}
+
+	return resp.Offer.MustGet(), resp.TradeRecord.ToPointer(), nil
+}
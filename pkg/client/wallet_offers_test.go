@@ -0,0 +1,55 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+const testAssetID = "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"
+
+func TestCreateCATOffer(t *testing.T) {
+	var sent client.CreateOfferForIDsOptions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_, _ = w.Write([]byte(`{"success":true,"offer":"offer1qqr83wcuu2rykcmqvpsxzgqq","trade_record":{
+			"trade_id":"0x0505050505050505050505050505050505050505050505050505050505050505",
+			"status":"PENDING_ACCEPT",
+			"is_my_offer":true,
+			"summary":{"offered":{"xch":1000},"requested":{"` + testAssetID + `":5000},"fees":0,
+				"infos":{"` + testAssetID + `":{"type":"CAT","tail":"0x` + testAssetID + `"}}}
+		}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cli := client.NewClient("127.0.0.1:1", client.WithWalletEndpoint(endpointOf(server)), client.WithBasePath(""))
+
+	offer, trade, err := cli.CreateOffer(context.Background(),
+		map[string]uint64{client.XCHAssetID: 1000},
+		map[string]uint64{"0x" + testAssetID: 5000},
+		10,
+	)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "offer1qqr83wcuu2rykcmqvpsxzgqq", offer)
+	assert.Equal(t, client.TradeStatusPendingAccept, trade.Status)
+	assert.Equal(t, "CAT", trade.Summary.Infos[testAssetID].Type())
+
+	assert.Equal(t, map[string]int64{"1": -1000, testAssetID: 5000}, sent.Offer)
+	assert.Equal(t, client.CATDriver(testAssetID), sent.DriverDict[testAssetID])
+	assert.Equal(t, uint64(10), sent.Fee)
+
+	_, _, err = cli.CreateOffer(context.Background(),
+		map[string]uint64{testAssetID: 1},
+		map[string]uint64{testAssetID: 2},
+		0,
+	)
+	assert.NotNil(t, err)
+}
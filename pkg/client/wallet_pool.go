@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// PoolSingletonState is the state of a plot NFT singleton
+type PoolSingletonState uint8
+
+// Plot NFT states
+const (
+	PoolSelfPooling   PoolSingletonState = 1
+	PoolLeavingPool   PoolSingletonState = 2 // waiting out the relative lock height of the pool it leaves
+	PoolFarmingToPool PoolSingletonState = 3
+)
+
+var poolSingletonStateNames = map[PoolSingletonState]string{
+	PoolSelfPooling:   "SELF_POOLING",
+	PoolLeavingPool:   "LEAVING_POOL",
+	PoolFarmingToPool: "FARMING_TO_POOL",
+}
+
+// String returns the name the wallet uses for the state
+func (s PoolSingletonState) String() string {
+	if name, ok := poolSingletonStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_%d", s)
+}
+
+// PoolState is a state of a plot NFT singleton, as stored on chain
+type PoolState struct {
+	Version            uint8              `json:"version"`
+	State              PoolSingletonState `json:"state"`
+	TargetPuzzleHash   types.Bytes32      `json:"target_puzzle_hash"` // where rewards go, the pool or the owner
+	OwnerPubkey        types.G1Element    `json:"owner_pubkey"`
+	PoolURL            mo.Option[string]  `json:"pool_url"`
+	RelativeLockHeight uint32             `json:"relative_lock_height"`
+}
+
+// PoolWalletInfo is the state of a plot NFT as tracked by its pool wallet
+type PoolWalletInfo struct {
+	Current               PoolState            `json:"current"`
+	Target                mo.Option[PoolState] `json:"target"` // set while a pool switch is in progress
+	LauncherCoin          types.Coin           `json:"launcher_coin"`
+	LauncherID            types.Bytes32        `json:"launcher_id"`
+	P2SingletonPuzzleHash types.Bytes32        `json:"p2_singleton_puzzle_hash"` // the pool contract address plots are created with
+	CurrentInner          HexBytes             `json:"current_inner"`
+	TipSingletonCoinID    types.Bytes32        `json:"tip_singleton_coin_id"`
+	SingletonBlockHeight  uint32               `json:"singleton_block_height"`
+}
+
+// InitialPoolState is the state a new plot NFT is created in
+type InitialPoolState struct {
+	State              PoolSingletonState
+	TargetPuzzleHash   mo.Option[types.Bytes32] // FARMING_TO_POOL only
+	PoolURL            string                   // FARMING_TO_POOL only
+	RelativeLockHeight uint32                   // FARMING_TO_POOL only
+}
+
+// MarshalJSON encodes the state by name as create_new_wallet expects, FARMING_TO_POOL needs the pool
+// target puzzle hash, url and relative lock height
+func (s InitialPoolState) MarshalJSON() ([]byte, error) {
+	if s.State != PoolSelfPooling && s.State != PoolFarmingToPool {
+		return nil, fmt.Errorf("plot nft cannot be created in state %v", s.State)
+	}
+	if s.State == PoolFarmingToPool {
+		switch {
+		case s.TargetPuzzleHash.IsAbsent():
+			return nil, fmt.Errorf("plot nft farming to a pool needs the pool target puzzle hash")
+		case s.PoolURL == "":
+			return nil, fmt.Errorf("plot nft farming to a pool needs the pool url")
+		case s.RelativeLockHeight == 0:
+			return nil, fmt.Errorf("plot nft farming to a pool needs the relative lock height")
+		}
+	}
+
+	state := map[string]interface{}{
+		"state": s.State.String(),
+	}
+	if s.State == PoolFarmingToPool {
+		state["target_puzzle_hash"] = s.TargetPuzzleHash
+		state["pool_url"] = s.PoolURL
+		state["relative_lock_height"] = s.RelativeLockHeight
+	}
+	return json.Marshal(state)
+}
+
+// CreatePoolWalletOptions options for creating a plot NFT through create_new_wallet
+type CreatePoolWalletOptions struct {
+	InitialTargetState InitialPoolState `json:"initial_target_state"`
+	Fee                uint64           `json:"fee"` // not required
+}
+
+// createPoolWalletRequest is the create_new_wallet body of CreatePoolWallet
+type createPoolWalletRequest struct {
+	WalletType string `json:"wallet_type"`
+	Mode       string `json:"mode"`
+	CreatePoolWalletOptions
+}
+
+// CreatePoolWalletResponse response from create_new_wallet for a pool wallet
+type CreatePoolWalletResponse struct {
+	Response
+	TotalFee              mo.Option[uint64]                  `json:"total_fee"`
+	Transaction           mo.Option[types.TransactionRecord] `json:"transaction"`
+	LauncherID            mo.Option[types.Bytes32]           `json:"launcher_id"`
+	P2SingletonPuzzleHash mo.Option[types.Bytes32]           `json:"p2_singleton_puzzle_hash"`
+}
+
+// CreatePoolWallet creates a plot NFT, its pool wallet shows up once the launcher is confirmed
+func (s *WalletService) CreatePoolWallet(ctx context.Context, opts *CreatePoolWalletOptions) (*CreatePoolWalletResponse, *http.Response, error) {
+	create := &createPoolWalletRequest{
+		WalletType: "pool_wallet",
+		Mode:       "new",
+	}
+	if opts != nil {
+		create.CreatePoolWalletOptions = *opts
+	}
+
+	request, err := s.NewRequest(ctx, "create_new_wallet", create)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreatePoolWalletResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWJoinPoolOptions options for pw_join_pool
+type PWJoinPoolOptions struct {
+	WalletID           uint32        `json:"wallet_id"`
+	TargetPuzzleHash   types.Bytes32 `json:"target_puzzlehash"`
+	PoolURL            string        `json:"pool_url"`
+	RelativeLockHeight uint32        `json:"relative_lock_height"`
+	Fee                uint64        `json:"fee"` // not required
+}
+
+// PWTransitionResponse response from pw_join_pool and pw_self_pool
+type PWTransitionResponse struct {
+	Response
+	TotalFee       mo.Option[uint64]                  `json:"total_fee"`
+	Transaction    mo.Option[types.TransactionRecord] `json:"transaction"`
+	FeeTransaction mo.Option[types.TransactionRecord] `json:"fee_transaction"`
+}
+
+// PWJoinPool points the plot NFT of a pool wallet to a pool, leaving the current pool first if needed
+func (s *WalletService) PWJoinPool(ctx context.Context, opts *PWJoinPoolOptions) (*PWTransitionResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "pw_join_pool", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWTransitionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWSelfPoolOptions options for pw_self_pool
+type PWSelfPoolOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Fee      uint64 `json:"fee"` // not required
+}
+
+// PWSelfPool makes the plot NFT of a pool wallet farm to its owner
+func (s *WalletService) PWSelfPool(ctx context.Context, opts *PWSelfPoolOptions) (*PWTransitionResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "pw_self_pool", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWTransitionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWAbsorbRewardsOptions options for pw_absorb_rewards
+type PWAbsorbRewardsOptions struct {
+	WalletID      uint32  `json:"wallet_id"`
+	Fee           uint64  `json:"fee"`                        // not required
+	MaxSpendsInTx *uint32 `json:"max_spends_in_tx,omitempty"` // reward coins claimed per transaction
+}
+
+// PWAbsorbRewardsResponse response from pw_absorb_rewards
+type PWAbsorbRewardsResponse struct {
+	Response
+	State          mo.Option[PoolWalletInfo]          `json:"state"`
+	Transaction    mo.Option[types.TransactionRecord] `json:"transaction"`
+	FeeTransaction mo.Option[types.TransactionRecord] `json:"fee_transaction"`
+}
+
+// PWAbsorbRewards claims the farmer rewards of a self pooling plot NFT into the wallet
+func (s *WalletService) PWAbsorbRewards(ctx context.Context, opts *PWAbsorbRewardsOptions) (*PWAbsorbRewardsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "pw_absorb_rewards", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWAbsorbRewardsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWStatusOptions options for pw_status
+type PWStatusOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// PWStatusResponse response from pw_status
+type PWStatusResponse struct {
+	Response
+	State                   mo.Option[PoolWalletInfo]            `json:"state"`
+	UnconfirmedTransactions mo.Option[[]types.TransactionRecord] `json:"unconfirmed_transactions"`
+}
+
+// PWStatus returns the plot NFT state of a pool wallet
+func (s *WalletService) PWStatus(ctx context.Context, opts *PWStatusOptions) (*PWStatusResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "pw_status", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWStatusResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestPoolWallet(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		_, _ = w.Write([]byte(`{"success":true,"state":{
+			"current":{"version":1,"state":3,"pool_url":"https://pool.example","relative_lock_height":32},
+			"target":{"version":1,"state":1,"pool_url":null,"relative_lock_height":0},
+			"singleton_block_height":100
+		}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	status, _, err := service.PWStatus(context.Background(), &client.PWStatusOptions{WalletID: 2})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	state := status.State.MustGet()
+	assert.Equal(t, client.PoolFarmingToPool, state.Current.State)
+	assert.Equal(t, "https://pool.example", state.Current.PoolURL.OrEmpty())
+	assert.Equal(t, client.PoolSelfPooling, state.Target.MustGet().State)
+	assert.False(t, state.Target.MustGet().PoolURL.IsPresent())
+
+	_, _, err = service.CreatePoolWallet(context.Background(), &client.CreatePoolWalletOptions{
+		InitialTargetState: client.InitialPoolState{
+			State:              client.PoolFarmingToPool,
+			TargetPuzzleHash:   mo.Some(types.Bytes32{1}),
+			PoolURL:            "https://pool.example",
+			RelativeLockHeight: 32,
+		},
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	sent := bodies["/create_new_wallet"]
+	assert.Equal(t, "pool_wallet", sent["wallet_type"])
+	assert.Equal(t, "new", sent["mode"])
+	assert.Equal(t, "FARMING_TO_POOL", sent["initial_target_state"].(map[string]interface{})["state"])
+
+	_, _, err = service.CreatePoolWallet(context.Background(), &client.CreatePoolWalletOptions{
+		InitialTargetState: client.InitialPoolState{State: client.PoolLeavingPool},
+	})
+	assert.NotNil(t, err)
+
+	for _, state := range []client.InitialPoolState{
+		{State: client.PoolFarmingToPool, PoolURL: "https://pool.example", RelativeLockHeight: 32},
+		{State: client.PoolFarmingToPool, TargetPuzzleHash: mo.Some(types.Bytes32{1}), RelativeLockHeight: 32},
+		{State: client.PoolFarmingToPool, TargetPuzzleHash: mo.Some(types.Bytes32{1}), PoolURL: "https://pool.example"},
+	} {
+		_, _, err = service.CreatePoolWallet(context.Background(), &client.CreatePoolWalletOptions{
+			InitialTargetState: state,
+		})
+		assert.NotNil(t, err)
+	}
+
+	// without options there is no initial state to create the plot NFT in
+	_, _, err = service.CreatePoolWallet(context.Background(), nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// SigningMode is how the wallet turns a message into the signed bytes
+type SigningMode string
+
+// Signing modes
+const (
+	// SigningModeCHIP0002 signs the utf8 message wrapped as defined by CHIP-0002, the default
+	SigningModeCHIP0002 SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:CHIP-0002_"
+	// SigningModeCHIP0002Hex signs the hex decoded message wrapped as defined by CHIP-0002
+	SigningModeCHIP0002Hex SigningMode = "hexinput_BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:CHIP-0002_"
+	// SigningModeBLSUTF8 signs the utf8 message as is, augmented with the public key
+	SigningModeBLSUTF8 SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:utf8input_"
+	// SigningModeBLSHex signs the hex decoded message as is, augmented with the public key
+	SigningModeBLSHex SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:hexinput_"
+)
+
+// signFlags returns the is_hex and safe_mode flags the sign calls take for a mode
+func (m SigningMode) signFlags() (isHex, safeMode bool, err error) {
+	switch m {
+	case "", SigningModeCHIP0002:
+		return false, true, nil
+	case SigningModeCHIP0002Hex:
+		return true, true, nil
+	case SigningModeBLSUTF8:
+		return false, false, nil
+	case SigningModeBLSHex:
+		return true, false, nil
+	}
+	return false, false, fmt.Errorf("signing mode %v cannot be requested", m)
+}
+
+// signRequest is the body of sign_message_by_address and sign_message_by_id
+type signRequest struct {
+	Address  string `json:"address,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Message  string `json:"message"`
+	IsHex    bool   `json:"is_hex"`
+	SafeMode bool   `json:"safe_mode"`
+}
+
+func newSignRequest(mode SigningMode, message string) (*signRequest, error) {
+	isHex, safeMode, err := mode.signFlags()
+	if err != nil {
+		return nil, err
+	}
+	return &signRequest{
+		Message:  message,
+		IsHex:    isHex,
+		SafeMode: safeMode,
+	}, nil
+}
+
+// SignMessageByAddressOptions options for sign_message_by_address
+type SignMessageByAddressOptions struct {
+	Address string
+	Message string      // utf8, or hex for the hex modes
+	Mode    SigningMode // defaults to SigningModeCHIP0002
+}
+
+// MarshalJSON encodes the signing mode as the flags the wallet takes
+func (o *SignMessageByAddressOptions) MarshalJSON() ([]byte, error) {
+	request, err := newSignRequest(o.Mode, o.Message)
+	if err != nil {
+		return nil, err
+	}
+	request.Address = o.Address
+	return json.Marshal(request)
+}
+
+// SignMessageByAddressResponse response from sign_message_by_address
+type SignMessageByAddressResponse struct {
+	Response
+	PubKey      mo.Option[types.G1Element] `json:"pubkey"`
+	Signature   mo.Option[types.G2Element] `json:"signature"`
+	SigningMode mo.Option[SigningMode]     `json:"signing_mode"`
+}
+
+// SignMessageByAddress signs a message with the key of a wallet address
+func (s *WalletService) SignMessageByAddress(ctx context.Context, opts *SignMessageByAddressOptions) (*SignMessageByAddressResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "sign_message_by_address", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SignMessageByAddressResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SignMessageByIDOptions options for sign_message_by_id
+type SignMessageByIDOptions struct {
+	ID      string      // did:chia:... or nft1...
+	Message string      // utf8, or hex for the hex modes
+	Mode    SigningMode // defaults to SigningModeCHIP0002
+}
+
+// MarshalJSON encodes the signing mode as the flags the wallet takes
+func (o *SignMessageByIDOptions) MarshalJSON() ([]byte, error) {
+	request, err := newSignRequest(o.Mode, o.Message)
+	if err != nil {
+		return nil, err
+	}
+	request.ID = o.ID
+	return json.Marshal(request)
+}
+
+// SignMessageByIDResponse response from sign_message_by_id
+type SignMessageByIDResponse struct {
+	Response
+	PubKey       mo.Option[types.G1Element] `json:"pubkey"`
+	Signature    mo.Option[types.G2Element] `json:"signature"`
+	LatestCoinID mo.Option[types.Bytes32]   `json:"latest_coin_id"`
+	SigningMode  mo.Option[SigningMode]     `json:"signing_mode"`
+}
+
+// SignMessageByID signs a message with the owner key of a DID or an NFT
+func (s *WalletService) SignMessageByID(ctx context.Context, opts *SignMessageByIDOptions) (*SignMessageByIDResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "sign_message_by_id", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SignMessageByIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VerifySignatureOptions options for verify_signature
+type VerifySignatureOptions struct {
+	PubKey      types.G1Element `json:"pubkey"`
+	Message     string          `json:"message"`
+	Signature   types.G2Element `json:"signature"`
+	Address     string          `json:"address,omitempty"`      // also check the public key belongs to the address
+	SigningMode SigningMode     `json:"signing_mode,omitempty"` // the mode the signer returned, defaults to plain BLS
+}
+
+// VerifySignatureResponse response from verify_signature
+type VerifySignatureResponse struct {
+	Response
+	IsValid mo.Option[bool] `json:"isValid"`
+}
+
+// VerifySignature checks a message signature, Error tells why an invalid signature was rejected
+func (s *WalletService) VerifySignature(ctx context.Context, opts *VerifySignatureOptions) (*VerifySignatureResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "verify_signature", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VerifySignatureResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
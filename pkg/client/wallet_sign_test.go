@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+func TestSignMessageModes(t *testing.T) {
+	sent := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_, _ = w.Write([]byte(`{"success":true,"signing_mode":"BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:hexinput_"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	resp, _, err := service.SignMessageByAddress(context.Background(), &client.SignMessageByAddressOptions{
+		Address: testAddress,
+		Message: "cafe",
+		Mode:    client.SigningModeBLSHex,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, client.SigningModeBLSHex, resp.SigningMode.OrEmpty())
+	assert.Equal(t, map[string]interface{}{
+		"address":   testAddress,
+		"message":   "cafe",
+		"is_hex":    true,
+		"safe_mode": false,
+	}, sent)
+
+	_, _, err = service.SignMessageByID(context.Background(), &client.SignMessageByIDOptions{
+		ID:      "did:chia:1qqqq",
+		Message: "hello",
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, true, sent["safe_mode"])
+	assert.Equal(t, false, sent["is_hex"])
+
+	_, _, err = service.SignMessageByID(context.Background(), &client.SignMessageByIDOptions{
+		ID:   "did:chia:1qqqq",
+		Mode: client.SigningMode("unknown"),
+	})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// LineageProof proves the parent of a singleton, ParentProofHash is only set on the lineage proofs of a VC
+type LineageProof struct {
+	ParentName      mo.Option[types.Bytes32] `json:"parent_name"`
+	InnerPuzzleHash mo.Option[types.Bytes32] `json:"inner_puzzle_hash"`
+	Amount          mo.Option[uint64]        `json:"amount"`
+	ParentProofHash mo.Option[types.Bytes32] `json:"parent_proof_hash"`
+}
+
+// VerifiedCredential is a VC singleton, ProofHash is the root of the proofs it currently attests to
+type VerifiedCredential struct {
+	Coin                  types.Coin               `json:"coin"`
+	SingletonLineageProof LineageProof             `json:"singleton_lineage_proof"`
+	EMLLineageProof       LineageProof             `json:"eml_lineage_proof"`
+	LauncherID            types.Bytes32            `json:"launcher_id"`
+	InnerPuzzleHash       types.Bytes32            `json:"inner_puzzle_hash"`
+	ProofProvider         types.Bytes32            `json:"proof_provider"`
+	ProofHash             mo.Option[types.Bytes32] `json:"proof_hash"`
+}
+
+// VCRecord is a VC tracked by the wallet, CoinID is only set by vc_get_list
+type VCRecord struct {
+	VC                VerifiedCredential       `json:"vc"`
+	ConfirmedAtHeight uint32                   `json:"confirmed_at_height"`
+	CoinID            mo.Option[types.Bytes32] `json:"coin_id"`
+}
+
+// VCProofs maps the keys a VC attests to, e.g. "kyc", to their values
+type VCProofs map[string]string
+
+// VCMintOptions options for vc_mint, DIDID is the did:chia address of the proof provider
+type VCMintOptions struct {
+	DIDID         string `json:"did_id"`
+	TargetAddress string `json:"target_address,omitempty"` // the wallet itself when empty
+	Fee           uint64 `json:"fee"`                      // not required
+}
+
+// VCMintResponse response from vc_mint
+type VCMintResponse struct {
+	Response
+	VCRecord     mo.Option[VCRecord]                  `json:"vc_record"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// VCMint mints a VC with a DID of the wallet as proof provider
+func (s *WalletService) VCMint(ctx context.Context, opts *VCMintOptions) (*VCMintResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_mint", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCMintResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCGetOptions options for vc_get, VCID is the launcher id of the VC
+type VCGetOptions struct {
+	VCID string `json:"vc_id"`
+}
+
+// VCGetResponse response from vc_get, VCRecord is absent when the wallet does not track the VC
+type VCGetResponse struct {
+	Response
+	VCRecord mo.Option[VCRecord] `json:"vc_record"`
+}
+
+// VCGet returns a VC of the wallet
+func (s *WalletService) VCGet(ctx context.Context, opts *VCGetOptions) (*VCGetResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_get", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCGetResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCGetListOptions options for vc_get_list
+type VCGetListOptions struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end,omitempty"` // the wallet default is used when 0
+}
+
+// VCGetListResponse response from vc_get_list, Proofs holds the known proofs keyed by the proof hash of the VCs
+type VCGetListResponse struct {
+	Response
+	VCRecords mo.Option[[]VCRecord]          `json:"vc_records"`
+	Proofs    mo.Option[map[string]VCProofs] `json:"proofs"`
+}
+
+// VCGetList returns a page of the VCs of the wallet
+func (s *WalletService) VCGetList(ctx context.Context, opts *VCGetListOptions) (*VCGetListResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_get_list", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCGetListResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCSpendOptions options for vc_spend, NewProofHash updates the proofs and needs the wallet to hold the provider DID
+type VCSpendOptions struct {
+	VCID                 string         `json:"vc_id"`
+	NewPuzhash           *types.Bytes32 `json:"new_puzhash,omitempty"`
+	NewProofHash         *types.Bytes32 `json:"new_proof_hash,omitempty"`
+	ProviderInnerPuzhash *types.Bytes32 `json:"provider_inner_puzhash,omitempty"`
+	Fee                  uint64         `json:"fee"` // not required
+}
+
+// VCTransactionsResponse response from vc_spend, vc_revoke and crcat_approve_pending
+type VCTransactionsResponse struct {
+	Response
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// VCSpend spends a VC to move it or to update its proofs
+func (s *WalletService) VCSpend(ctx context.Context, opts *VCSpendOptions) (*VCTransactionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_spend", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCTransactionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCAddProofsOptions options for vc_add_proofs
+type VCAddProofsOptions struct {
+	Proofs VCProofs `json:"proofs"`
+}
+
+// VCAddProofsResponse response from vc_add_proofs
+type VCAddProofsResponse struct {
+	Response
+}
+
+// VCAddProofs stores proofs in the wallet so that VCs can be spent with their root as NewProofHash
+func (s *WalletService) VCAddProofs(ctx context.Context, opts *VCAddProofsOptions) (*VCAddProofsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_add_proofs", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCAddProofsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCGetProofsForRootOptions options for vc_get_proofs_for_root
+type VCGetProofsForRootOptions struct {
+	Root string `json:"root"`
+}
+
+// VCGetProofsForRootResponse response from vc_get_proofs_for_root
+type VCGetProofsForRootResponse struct {
+	Response
+	Proofs mo.Option[VCProofs] `json:"proofs"`
+}
+
+// VCGetProofsForRoot returns the stored proofs of a proof hash
+func (s *WalletService) VCGetProofsForRoot(ctx context.Context, opts *VCGetProofsForRootOptions) (*VCGetProofsForRootResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_get_proofs_for_root", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCGetProofsForRootResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VCRevokeOptions options for vc_revoke, VCParentID is the parent coin id of the current VC coin
+type VCRevokeOptions struct {
+	VCParentID string `json:"vc_parent_id"`
+	Fee        uint64 `json:"fee"` // not required
+}
+
+// VCRevoke revokes a VC the wallet is the proof provider of
+func (s *WalletService) VCRevoke(ctx context.Context, opts *VCRevokeOptions) (*VCTransactionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "vc_revoke", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCTransactionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CRCATApprovePendingOptions options for crcat_approve_pending
+type CRCATApprovePendingOptions struct {
+	WalletID         uint32 `json:"wallet_id"`
+	MinAmountToClaim uint64 `json:"min_amount_to_claim"`
+	Fee              uint64 `json:"fee"` // not required
+}
+
+// CRCATApprovePending claims the credential restricted CATs received by the wallet with its VC
+func (s *WalletService) CRCATApprovePending(ctx context.Context, opts *CRCATApprovePendingOptions) (*VCTransactionsResponse, *http.Response, error) {
+	request, err := s.NewRequest(ctx, "crcat_approve_pending", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VCTransactionsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+const testVCRecord = `{"vc":{
+	"coin":{"parent_coin_info":"0x0101010101010101010101010101010101010101010101010101010101010101",
+		"puzzle_hash":"0x0202020202020202020202020202020202020202020202020202020202020202","amount":1},
+	"singleton_lineage_proof":{"parent_name":"0x0303030303030303030303030303030303030303030303030303030303030303",
+		"inner_puzzle_hash":null,"amount":1},
+	"eml_lineage_proof":{"parent_name":null,"inner_puzzle_hash":null,"amount":null,"parent_proof_hash":null},
+	"launcher_id":"0x0404040404040404040404040404040404040404040404040404040404040404",
+	"inner_puzzle_hash":"0x0505050505050505050505050505050505050505050505050505050505050505",
+	"proof_provider":"0x0606060606060606060606060606060606060606060606060606060606060606",
+	"proof_hash":null
+},"confirmed_at_height":42}`
+
+func TestVerifiedCredentials(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		switch r.URL.Path {
+		case "/vc_mint":
+			_, _ = w.Write([]byte(`{"success":true,"vc_record":` + testVCRecord + `,"transactions":[]}`))
+		case "/vc_get_list":
+			_, _ = w.Write([]byte(`{"success":true,"vc_records":[` + testVCRecord + `],"proofs":{"0x07":{"kyc":"1"}}}`))
+		case "/vc_get":
+			_, _ = w.Write([]byte(`{"success":true,"vc_record":null}`))
+		default:
+			_, _ = w.Write([]byte(`{"success":true,"transactions":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	service := client.NewWalletService(client.ServiceConfig{
+		Endpoint: endpointOf(server),
+		Timeout:  client.DefaultTimeout,
+	})
+
+	minted, _, err := service.VCMint(context.Background(), &client.VCMintOptions{DIDID: "did:chia:1provider"})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	record := minted.VCRecord.MustGet()
+	assert.Equal(t, uint32(42), record.ConfirmedAtHeight)
+	assert.Equal(t, types.Bytes32{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+		record.VC.LauncherID)
+	assert.True(t, record.VC.SingletonLineageProof.ParentName.IsPresent())
+	assert.False(t, record.VC.SingletonLineageProof.InnerPuzzleHash.IsPresent())
+	assert.False(t, record.VC.ProofHash.IsPresent())
+	assert.NotContains(t, bodies["/vc_mint"], "target_address")
+
+	list, _, err := service.VCGetList(context.Background(), &client.VCGetListOptions{})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(list.VCRecords.MustGet()))
+	assert.Equal(t, client.VCProofs{"kyc": "1"}, list.Proofs.MustGet()["0x07"])
+
+	got, _, err := service.VCGet(context.Background(), &client.VCGetOptions{VCID: "0x04"})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.False(t, got.VCRecord.IsPresent())
+
+	proofHash := types.Bytes32{7}
+	_, _, err = service.VCSpend(context.Background(), &client.VCSpendOptions{VCID: "0x04", NewProofHash: &proofHash})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, proofHash.String(), bodies["/vc_spend"]["new_proof_hash"])
+	assert.NotContains(t, bodies["/vc_spend"], "new_puzhash")
+
+	_, _, err = service.CRCATApprovePending(context.Background(), &client.CRCATApprovePendingOptions{
+		WalletID:         4,
+		MinAmountToClaim: 1000,
+	})
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+	assert.Equal(t, float64(1000), bodies["/crcat_approve_pending"]["min_amount_to_claim"])
+}
@@ -0,0 +1,441 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/samber/mo"
+)
+
+const (
+	DefaultDaemonPort        = 55400
+	DefaultWebsocketService  = "wallet_ui" // services push their events to this name
+	DefaultReconnectInterval = 5 * time.Second
+	DefaultEventBuffer       = 64
+)
+
+// Wallet state_changed states
+const (
+	StateCoinAdded          = "coin_added"
+	StateCoinRemoved        = "coin_removed"
+	StateTxUpdate           = "tx_update" // a transaction got confirmed or its status changed
+	StateNewBlock           = "new_block"
+	StateSyncChanged        = "sync_changed"
+	StatePendingTransaction = "pending_transaction"
+	StateWalletCreated      = "wallet_created"
+)
+
+var ErrWebsocketClosed = errors.New("websocket client closed")
+
+// StateChangedEvent is a state_changed message a service pushed through the daemon
+type StateChangedEvent struct {
+	Origin         string            `json:"-"`
+	State          string            `json:"state"`
+	WalletID       mo.Option[uint32] `json:"wallet_id"`
+	Success        bool              `json:"success"`
+	AdditionalData json.RawMessage   `json:"additional_data,omitempty"`
+}
+
+// BlockchainStateEvent is the blockchain state the full node pushes on a new peak
+type BlockchainStateEvent struct {
+	Origin string
+	types.BlockchainState
+}
+
+// WebsocketClient talks to the chia daemon over its websocket, it forwards requests to services
+// and delivers the events services push to the registered service name
+type WebsocketClient struct {
+	Endpoint          string // host:port of the daemon
+	TLS               *TLSConfig
+	ServiceName       string // origin of the requests, events addressed to it are delivered
+	Timeout           time.Duration
+	ReconnectInterval time.Duration
+	OnReconnect       func() // called after a dropped connection is restored and resubscribed, events may have been missed
+
+	connectMu sync.Mutex // serializes Connect
+	connMu    sync.Mutex
+	conn      *websocket.Conn
+	running   bool // a listen loop owns the connection, also while it reconnects
+	writeMu   sync.Mutex
+	services  []string
+
+	mu       sync.Mutex
+	pending  map[string]chan *types.WebsocketResponse
+	handlers map[uuid.UUID]func(*types.WebsocketResponse)
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// NewWebsocketClient creates a daemon client, Connect must be called before use
+func NewWebsocketClient(endpoint string, tlsConfig *TLSConfig) *WebsocketClient {
+	return &WebsocketClient{
+		Endpoint:          endpoint,
+		TLS:               tlsConfig,
+		ServiceName:       DefaultWebsocketService,
+		Timeout:           DefaultTimeout,
+		ReconnectInterval: DefaultReconnectInterval,
+		pending:           map[string]chan *types.WebsocketResponse{},
+		handlers:          map[uuid.UUID]func(*types.WebsocketResponse){},
+		closed:            make(chan struct{}),
+	}
+}
+
+// DefaultWebsocketClient creates a daemon client using the daemon certificates found in CHIA_ROOT
+func DefaultWebsocketClient(endpoint string) (*WebsocketClient, error) {
+	tlsConfig, err := TLSConfigFromChiaRoot("", rpcinterface.ServiceDaemon)
+	if err != nil {
+		return nil, err
+	}
+	return NewWebsocketClient(endpoint, tlsConfig), nil
+}
+
+// Connect dials the daemon, registers ServiceName and starts delivering events. It does nothing when the
+// client is already connected, and closes the connection again when ServiceName cannot be registered
+func (c *WebsocketClient) Connect(ctx context.Context) error {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	select {
+	case <-c.closed:
+		return ErrWebsocketClosed
+	default:
+	}
+
+	c.connMu.Lock()
+	running := c.running
+	c.connMu.Unlock()
+	if running {
+		return nil
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.connMu.Lock()
+	c.running = true
+	c.connMu.Unlock()
+	go c.listen(conn)
+
+	err = c.Subscribe(ctx, c.ServiceName)
+	if err != nil {
+		// detach the connection before closing it, so that listen returns instead of reconnecting
+		c.connMu.Lock()
+		c.conn = nil
+		c.running = false
+		c.connMu.Unlock()
+		conn.Close()
+		return err
+	}
+	return nil
+}
+
+func (c *WebsocketClient) dial(ctx context.Context) (*websocket.Conn, error) {
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: c.Timeout,
+	}
+
+	scheme := "ws"
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.Load()
+		if err != nil {
+			return nil, err
+		}
+		dialer.TLSClientConfig = tlsConfig
+		scheme = "wss"
+	}
+
+	conn, _, err := dialer.DialContext(ctx, fmt.Sprintf("%v://%v/", scheme, c.Endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect daemon,err: %v", err)
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+	return conn, nil
+}
+
+// Subscribe registers another service name with the daemon, it is registered again after a reconnect
+func (c *WebsocketClient) Subscribe(ctx context.Context, service string) error {
+	c.connMu.Lock()
+	known := false
+	for _, s := range c.services {
+		known = known || s == service
+	}
+	if !known {
+		c.services = append(c.services, service)
+	}
+	c.connMu.Unlock()
+
+	return c.register(ctx, service)
+}
+
+func (c *WebsocketClient) register(ctx context.Context, service string) error {
+	return c.Request(ctx, "daemon", "register_service", types.WebsocketSubscription{Service: service}, nil)
+}
+
+// Request sends command to destination, e.g. daemon or chia_full_node, and decodes the response data into v
+func (c *WebsocketClient) Request(ctx context.Context, destination, command string, data interface{}, v interface{}) error {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	request := &types.WebsocketRequest{
+		Command:     command,
+		Origin:      c.ServiceName,
+		Destination: destination,
+		RequestID:   uuid.New().String(),
+		Data:        data,
+	}
+
+	respChan := make(chan *types.WebsocketResponse, 1)
+	c.mu.Lock()
+	c.pending[request.RequestID] = respChan
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, request.RequestID)
+		c.mu.Unlock()
+	}()
+
+	err := c.write(request)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(c.Timeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-respChan:
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Data, v)
+	case <-timer.C:
+		return fmt.Errorf("timeout waiting for %v response from %v", command, destination)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.closed:
+		return ErrWebsocketClosed
+	}
+}
+
+func (c *WebsocketClient) write(request *types.WebsocketRequest) error {
+	c.connMu.Lock()
+	conn := c.conn
+	c.connMu.Unlock()
+	if conn == nil {
+		return fmt.Errorf("daemon not connected")
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteJSON(request)
+}
+
+// listen reads messages until the connection drops, then reconnects unless the client is closed
+// or Connect gave the connection up
+func (c *WebsocketClient) listen(conn *websocket.Conn) {
+	for {
+		resp := &types.WebsocketResponse{}
+		err := conn.ReadJSON(resp)
+		if err != nil {
+			conn.Close()
+			c.connMu.Lock()
+			detached := c.conn != conn
+			c.connMu.Unlock()
+			if detached || !c.reconnect() {
+				return
+			}
+			c.connMu.Lock()
+			conn = c.conn
+			c.connMu.Unlock()
+			continue
+		}
+
+		c.mu.Lock()
+		respChan, ok := c.pending[resp.RequestID]
+		handlers := make([]func(*types.WebsocketResponse), 0, len(c.handlers))
+		for _, handler := range c.handlers {
+			handlers = append(handlers, handler)
+		}
+		c.mu.Unlock()
+
+		if ok {
+			respChan <- resp
+			continue
+		}
+		for _, handler := range handlers {
+			handler(resp)
+		}
+	}
+}
+
+// reconnect dials until it succeeds or the client is closed, then registers the service names again
+func (c *WebsocketClient) reconnect() bool {
+	c.connMu.Lock()
+	c.conn = nil
+	services := append([]string{}, c.services...)
+	c.connMu.Unlock()
+
+	for {
+		select {
+		case <-c.closed:
+			return false
+		case <-time.After(c.ReconnectInterval):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+		conn, err := c.dial(ctx)
+		cancel()
+		if err != nil {
+			continue
+		}
+
+		select {
+		case <-c.closed:
+			conn.Close()
+			return false
+		default:
+		}
+
+		// responses are read by the listen loop, which is this goroutine, so register in the background
+		go func() {
+			for _, service := range services {
+				ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+				err := c.register(ctx, service)
+				cancel()
+				if err != nil {
+					conn.Close()
+					return
+				}
+			}
+			if c.OnReconnect != nil {
+				c.OnReconnect()
+			}
+		}()
+		return true
+	}
+}
+
+// Close stops reconnecting and closes the connection and every subscription channel
+func (c *WebsocketClient) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.closed)
+
+		c.connMu.Lock()
+		if c.conn != nil {
+			err = c.conn.Close()
+		}
+		c.connMu.Unlock()
+
+		c.mu.Lock()
+		handlers := c.handlers
+		c.handlers = map[uuid.UUID]func(*types.WebsocketResponse){}
+		c.mu.Unlock()
+		for _, handler := range handlers {
+			handler(nil)
+		}
+	})
+	return err
+}
+
+// addHandler registers fn for every pushed message, fn receives nil once the client is closed
+func (c *WebsocketClient) addHandler(fn func(*types.WebsocketResponse)) func() {
+	id := uuid.New()
+	c.mu.Lock()
+	c.handlers[id] = fn
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		_, ok := c.handlers[id]
+		delete(c.handlers, id)
+		c.mu.Unlock()
+		if ok {
+			fn(nil)
+		}
+	}
+}
+
+// subscribeEvents delivers the messages decode accepts on a channel of the given buffer.
+// Messages are dropped while the buffer is full, so consumers should reconcile state through the RPC
+func subscribeEvents[T any](c *WebsocketClient, buffer int, decode func(*types.WebsocketResponse) (T, bool)) (<-chan T, func()) {
+	if buffer <= 0 {
+		buffer = DefaultEventBuffer
+	}
+
+	events := make(chan T, buffer)
+	mu := sync.Mutex{}
+	done := false
+
+	unsubscribe := c.addHandler(func(resp *types.WebsocketResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return
+		}
+		if resp == nil {
+			done = true
+			close(events)
+			return
+		}
+		event, ok := decode(resp)
+		if !ok {
+			return
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	return events, unsubscribe
+}
+
+// SubscribeMessages delivers every message pushed to the registered service names
+func (c *WebsocketClient) SubscribeMessages(buffer int) (<-chan *types.WebsocketResponse, func()) {
+	return subscribeEvents(c, buffer, func(resp *types.WebsocketResponse) (*types.WebsocketResponse, bool) {
+		return resp, true
+	})
+}
+
+// SubscribeStateChanged delivers state_changed events such as coin_added or tx_update
+func (c *WebsocketClient) SubscribeStateChanged(buffer int) (<-chan StateChangedEvent, func()) {
+	return subscribeEvents(c, buffer, func(resp *types.WebsocketResponse) (StateChangedEvent, bool) {
+		event := StateChangedEvent{}
+		if resp.Command != "state_changed" || json.Unmarshal(resp.Data, &event) != nil {
+			return event, false
+		}
+		event.Origin = resp.Origin
+		return event, true
+	})
+}
+
+// SubscribeBlockchainState delivers the blockchain state the full node pushes on every new peak
+func (c *WebsocketClient) SubscribeBlockchainState(buffer int) (<-chan BlockchainStateEvent, func()) {
+	return subscribeEvents(c, buffer, func(resp *types.WebsocketResponse) (BlockchainStateEvent, bool) {
+		state := types.WebsocketBlockchainState{}
+		if resp.Command != "get_blockchain_state" || json.Unmarshal(resp.Data, &state) != nil {
+			return BlockchainStateEvent{}, false
+		}
+		return BlockchainStateEvent{
+			Origin:          resp.Origin,
+			BlockchainState: state.BlockchainState,
+		}, true
+	})
+}
@@ -0,0 +1,178 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NpoolPlatform/chia-client/pkg/client"
+)
+
+// daemonStandIn acknowledges register_service, then pushes a peak and a coin_added event.
+// The first connection is dropped afterwards to exercise the reconnect
+func daemonStandIn(t *testing.T, registrations *int32) http.Handler {
+	upgrader := websocket.Upgrader{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			req := &types.WebsocketRequest{}
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			if req.Command != "register_service" {
+				continue
+			}
+
+			n := atomic.AddInt32(registrations, 1)
+			_ = conn.WriteJSON(map[string]interface{}{
+				"command":     "register_service",
+				"origin":      "daemon",
+				"destination": req.Origin,
+				"request_id":  req.RequestID,
+				"data":        map[string]interface{}{"success": true},
+			})
+			_ = conn.WriteJSON(map[string]interface{}{
+				"command":     "get_blockchain_state",
+				"origin":      "chia_full_node",
+				"destination": "wallet_ui",
+				"data":        map[string]interface{}{"blockchain_state": map[string]interface{}{"peak": map[string]interface{}{"height": 100 + n}}},
+			})
+			_ = conn.WriteJSON(map[string]interface{}{
+				"command":     "state_changed",
+				"origin":      "chia_wallet",
+				"destination": "wallet_ui",
+				"data":        map[string]interface{}{"state": "coin_added", "wallet_id": 1, "success": true},
+			})
+			if n == 1 {
+				return
+			}
+		}
+	})
+}
+
+func TestWebsocketEventsAndReconnect(t *testing.T) {
+	root := newChiaRoot(t)
+	registrations := int32(0)
+	server := newMutualTLSServer(t, root, daemonStandIn(t, &registrations))
+
+	tlsConfig, err := client.TLSConfigFromChiaRoot(root, rpcinterface.ServiceDaemon)
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	ws := client.NewWebsocketClient(strings.TrimPrefix(server.URL, "https://"), tlsConfig)
+	ws.ReconnectInterval = 10 * time.Millisecond
+	reconnected := make(chan struct{}, 1)
+	ws.OnReconnect = func() { reconnected <- struct{}{} }
+
+	states, unsubscribe := ws.SubscribeBlockchainState(0)
+	defer unsubscribe()
+	changes, _ := ws.SubscribeStateChanged(0)
+
+	err = ws.Connect(context.Background())
+	if !assert.Nil(t, err) {
+		t.Fatal(err)
+	}
+
+	heights := []uint32{}
+	for len(heights) < 2 {
+		select {
+		case state := <-states:
+			assert.Equal(t, "chia_full_node", state.Origin)
+			heights = append(heights, state.Peak.OrEmpty().Height)
+		case change := <-changes:
+			assert.Equal(t, client.StateCoinAdded, change.State)
+			assert.Equal(t, uint32(1), change.WalletID.OrEmpty())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for events")
+		}
+	}
+	assert.Equal(t, []uint32{101, 102}, heights)
+
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for reconnect")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&registrations))
+
+	assert.Nil(t, ws.Close())
+	_, open := <-changes
+	for open {
+		_, open = <-changes
+	}
+}
+
+func TestWebsocketConnect(t *testing.T) {
+	connections, open, answer := int32(0), int32(0), int32(0)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&connections, 1)
+		atomic.AddInt32(&open, 1)
+		defer atomic.AddInt32(&open, -1)
+		defer conn.Close()
+
+		for {
+			req := &types.WebsocketRequest{}
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			if atomic.LoadInt32(&answer) == 0 {
+				continue
+			}
+			_ = conn.WriteJSON(map[string]interface{}{
+				"command":     req.Command,
+				"origin":      "daemon",
+				"destination": req.Origin,
+				"request_id":  req.RequestID,
+				"data":        map[string]interface{}{"success": true},
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ws := client.NewWebsocketClient(endpointOf(server), nil)
+	ws.Timeout = 100 * time.Millisecond
+	ws.ReconnectInterval = 10 * time.Millisecond
+	t.Cleanup(func() { _ = ws.Close() })
+
+	// the daemon does not answer register_service, the connection is closed and not redialed
+	err := ws.Connect(context.Background())
+	assert.NotNil(t, err)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&open) == 0 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+
+	// concurrent calls share one connection
+	atomic.StoreInt32(&answer, 1)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, ws.Connect(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&connections))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&open))
+}
@@ -0,0 +1,134 @@
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNoResponse       = errors.New("cannot get response from node")
+	ErrNodeNotSynced    = errors.New("node is not synced")
+	ErrNotInMempool     = errors.New("tx not in the mempool")
+	ErrDoubleSpend      = errors.New("coin already spent")
+	ErrInvalidSignature = errors.New("invalid aggregated signature")
+	ErrMempoolFull      = errors.New("mempool is full")
+	ErrFeeTooLow        = errors.New("fee too low")
+	ErrMempoolConflict  = errors.New("conflicts with a mempool item")
+	ErrAlreadyInMempool = errors.New("tx already in the mempool")
+	ErrQuorumNotReached = errors.New("nodes did not reach quorum")
+)
+
+// ErrHTTPStatus is returned when an RPC answers with a non 200 status code
+type ErrHTTPStatus struct {
+	Code int
+}
+
+func (e *ErrHTTPStatus) Error() string {
+	return fmt.Sprintf("failed to request, status code:%v", e.Code)
+}
+
+// RPCError is an error reported by the node in the response body.
+// Kind is one of the sentinel errors above when the message is recognized, so
+// errors.Is(err, ErrDoubleSpend) works on the returned value
+type RPCError struct {
+	Message string
+	Kind    error
+}
+
+func (e *RPCError) Error() string {
+	return e.Message
+}
+
+func (e *RPCError) Unwrap() error {
+	return e.Kind
+}
+
+// MempoolInclusionStatus is the status push_tx reports for a spend bundle
+type MempoolInclusionStatus string
+
+const (
+	MempoolSuccess MempoolInclusionStatus = "SUCCESS"
+	MempoolPending MempoolInclusionStatus = "PENDING"
+	MempoolFailed  MempoolInclusionStatus = "FAILED"
+)
+
+// ErrInclusion is returned by PushTX when the node did not accept the spend bundle
+type ErrInclusion struct {
+	Status MempoolInclusionStatus
+	Err    error // the reason reported by the node, may be nil
+}
+
+func (e *ErrInclusion) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("failed to push tx, status:%v", e.Status)
+	}
+	return fmt.Sprintf("failed to push tx, status:%v, err: %v", e.Status, e.Err)
+}
+
+func (e *ErrInclusion) Unwrap() error {
+	return e.Err
+}
+
+// messageKinds maps fragments of node error messages to sentinel errors,
+// mostly the names of chia's Err enum that the mempool reports
+var messageKinds = []struct {
+	fragment string
+	kind     error
+}{
+	{"not in the mempool", ErrNotInMempool},
+	{"DOUBLE_SPEND", ErrDoubleSpend},
+	{"BAD_AGGREGATE_SIGNATURE", ErrInvalidSignature},
+	{"INVALID_FEE_LOW_FEE", ErrMempoolFull},
+	{"INVALID_FEE_TOO_CLOSE_TO_ZERO", ErrFeeTooLow},
+	{"MEMPOOL_CONFLICT", ErrMempoolConflict},
+	{"ALREADY_INCLUDING_TRANSACTION", ErrAlreadyInMempool},
+	{"not synced", ErrNodeNotSynced},
+}
+
+// New turns the error message of an RPC response into an *RPCError
+func New(msg string) error {
+	err := &RPCError{Message: msg}
+	for _, mk := range messageKinds {
+		if strings.Contains(msg, mk.fragment) {
+			err.Kind = mk.kind
+			break
+		}
+	}
+	return err
+}
+
+// Retryable reports whether the failure is transient, so the same request may succeed later.
+// Rejections such as a double spend or a bad signature are permanent
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, ErrMempoolFull),
+		errors.Is(err, ErrMempoolConflict),
+		errors.Is(err, ErrNodeNotSynced),
+		errors.Is(err, ErrNoResponse):
+		return true
+	case errors.Is(err, ErrDoubleSpend),
+		errors.Is(err, ErrInvalidSignature),
+		errors.Is(err, ErrFeeTooLow):
+		return false
+	}
+
+	var statusErr *ErrHTTPStatus
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= http.StatusInternalServerError || statusErr.Code == http.StatusTooManyRequests
+	}
+
+	var inclusionErr *ErrInclusion
+	if errors.As(err, &inclusionErr) {
+		return inclusionErr.Status == MempoolPending
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,45 @@
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClassifiesMessage(t *testing.T) {
+	cases := []struct {
+		msg  string
+		kind error
+	}{
+		{"Tx id 0x1234 not in the mempool", ErrNotInMempool},
+		{"Failed to include transaction 0x1234, error DOUBLE_SPEND", ErrDoubleSpend},
+		{"Failed to include transaction 0x1234, error BAD_AGGREGATE_SIGNATURE", ErrInvalidSignature},
+		{"Failed to include transaction 0x1234, error INVALID_FEE_LOW_FEE", ErrMempoolFull},
+		{"Failed to include transaction 0x1234, error INVALID_FEE_TOO_CLOSE_TO_ZERO", ErrFeeTooLow},
+	}
+
+	for _, c := range cases {
+		err := New(c.msg)
+		assert.True(t, errors.Is(err, c.kind), c.msg)
+		assert.Equal(t, c.msg, err.Error())
+	}
+
+	var rpcErr *RPCError
+	err := New("unknown failure")
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Nil(t, rpcErr.Kind)
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, Retryable(New("error INVALID_FEE_LOW_FEE")))
+	assert.True(t, Retryable(ErrNodeNotSynced))
+	assert.True(t, Retryable(&ErrHTTPStatus{Code: 502}))
+	assert.True(t, Retryable(&ErrInclusion{Status: MempoolPending}))
+
+	assert.False(t, Retryable(nil))
+	assert.False(t, Retryable(&ErrHTTPStatus{Code: 404}))
+	assert.False(t, Retryable(&ErrInclusion{Status: MempoolFailed, Err: New("error DOUBLE_SPEND")}))
+	assert.False(t, Retryable(fmt.Errorf("wrapped: %w", New("error BAD_AGGREGATE_SIGNATURE"))))
+}